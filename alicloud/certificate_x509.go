@@ -0,0 +1,101 @@
+package alicloud
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math"
+	"slices"
+	"time"
+)
+
+// Minimum key sizes, in bits, below which a certificate is flagged as using weak cryptography.
+// Certificates with DSA keys are always flagged.
+const (
+	minimumRSAKeySize   = 2048
+	minimumECDSAKeySize = 256
+)
+
+// weakSignatureAlgorithms are the signature algorithms based on MD2, MD5 or SHA-1
+var weakSignatureAlgorithms = []x509.SignatureAlgorithm{
+	x509.MD2WithRSA,
+	x509.MD5WithRSA,
+	x509.SHA1WithRSA,
+	x509.DSAWithSHA1,
+	x509.ECDSAWithSHA1,
+}
+
+// certificateX509Details holds the details parsed from the leaf certificate of a PEM certificate chain
+type certificateX509Details struct {
+	KeyAlgorithm          string
+	KeySize               int
+	SignatureAlgorithm    string
+	SerialNumber          string
+	ChainLength           int
+	IntermediateIssuers   []string
+	OcspServers           []string
+	CrlDistributionPoints []string
+	DaysToExpiry          int
+	WeakCrypto            bool
+}
+
+// parseCertificateChain parses a PEM certificate chain, the first certificate being the leaf certificate
+func parseCertificateChain(chain string) (*certificateX509Details, error) {
+	var certificates []*x509.Certificate
+	rest := []byte(chain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificate found in PEM content")
+	}
+
+	leaf := certificates[0]
+	details := &certificateX509Details{
+		KeyAlgorithm:          leaf.PublicKeyAlgorithm.String(),
+		SignatureAlgorithm:    leaf.SignatureAlgorithm.String(),
+		SerialNumber:          leaf.SerialNumber.Text(16),
+		ChainLength:           len(certificates),
+		OcspServers:           leaf.OCSPServer,
+		CrlDistributionPoints: leaf.CRLDistributionPoints,
+		DaysToExpiry:          int(math.Floor(time.Until(leaf.NotAfter).Hours() / 24)),
+		WeakCrypto:            slices.Contains(weakSignatureAlgorithms, leaf.SignatureAlgorithm),
+	}
+
+	for _, intermediate := range certificates[1:] {
+		details.IntermediateIssuers = append(details.IntermediateIssuers, intermediate.Subject.String())
+	}
+
+	switch key := leaf.PublicKey.(type) {
+	case *rsa.PublicKey:
+		details.KeySize = key.N.BitLen()
+		details.WeakCrypto = details.WeakCrypto || details.KeySize < minimumRSAKeySize
+	case *ecdsa.PublicKey:
+		details.KeySize = key.Curve.Params().BitSize
+		details.WeakCrypto = details.WeakCrypto || details.KeySize < minimumECDSAKeySize
+	case ed25519.PublicKey:
+		details.KeySize = 256
+	}
+
+	// DSA is deprecated and not supported by browsers for TLS certificates
+	if leaf.PublicKeyAlgorithm == x509.DSA {
+		details.WeakCrypto = true
+	}
+
+	return details, nil
+}
@@ -0,0 +1,175 @@
+package alicloud
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/utils"
+	"github.com/alibabacloud-go/tea/dara"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	ossRetry "github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/retry"
+	ossTransport "github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/transport"
+	sls "github.com/aliyun/aliyun-log-go-sdk"
+	"golang.org/x/time/rate"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const (
+	// Defaults documented in config/alicloud.spc
+	defaultClientTimeout     = 10 * time.Second
+	defaultClientMaxAttempts = 3
+
+	// Upper bound for a single backoff delay between two attempts
+	maxClientRetryDelay = 20 * time.Second
+)
+
+// clientOptions holds the connection level settings of a service client
+type clientOptions struct {
+	// AutoRetry retries server and connection errors as well as throttled calls
+	AutoRetry   bool
+	MaxAttempts int
+	Timeout     time.Duration
+	// RateLimiter limits the requests of the service, nil if it is not rate limited
+	RateLimiter *rate.Limiter
+}
+
+// getClientOptions reads the "auto_retry", "max_retry_time", "timeout" and "rate_limits" arguments
+// from the connection config for the clients of a service
+func getClientOptions(connection *plugin.Connection, service string) (clientOptions, error) {
+	alicloudConfig := GetConfig(connection)
+
+	opts := clientOptions{
+		MaxAttempts: defaultClientMaxAttempts,
+		Timeout:     defaultClientTimeout,
+	}
+
+	if alicloudConfig.AutoRetry != nil {
+		opts.AutoRetry = *alicloudConfig.AutoRetry
+	}
+
+	if alicloudConfig.MaxRetryTime != nil {
+		if *alicloudConfig.MaxRetryTime < 1 {
+			return opts, fmt.Errorf("connection config has invalid value for \"max_retry_time\": %d, it must be greater than or equal to 1", *alicloudConfig.MaxRetryTime)
+		}
+		opts.MaxAttempts = *alicloudConfig.MaxRetryTime
+	}
+
+	if alicloudConfig.Timeout != nil {
+		if *alicloudConfig.Timeout < 1 {
+			return opts, fmt.Errorf("connection config has invalid value for \"timeout\": %d, it must be greater than or equal to 1", *alicloudConfig.Timeout)
+		}
+		opts.Timeout = time.Duration(*alicloudConfig.Timeout) * time.Second
+	}
+
+	// The ignore rules are only read once an API call fails, so they are validated with the
+	// other arguments, rather than being skipped when an error occurs
+	if _, err := getIgnoreErrorRules(connection); err != nil {
+		return opts, err
+	}
+
+	limiter, err := getServiceRateLimiter(connection, service)
+	if err != nil {
+		return opts, err
+	}
+	opts.RateLimiter = limiter
+
+	return opts, nil
+}
+
+// retryableErrorCodes returns the error codes of the API calls which are retried
+func (o clientOptions) retryableErrorCodes() []string {
+	if !o.AutoRetry {
+		return throttlingErrorCodes
+	}
+	return slices.Concat(throttlingErrorCodes, serverErrorCodes)
+}
+
+// applyToOpenAPIConfig sets the timeouts, rate limit and retry policy on an OpenAPI client config
+func (o clientOptions) applyToOpenAPIConfig(cfg *openapi.Config) {
+	timeoutMs := int(o.Timeout / time.Millisecond)
+	cfg.ConnectTimeout = dara.Int(timeoutMs)
+	cfg.ReadTimeout = dara.Int(timeoutMs)
+
+	if o.RateLimiter != nil {
+		// The OpenAPI client times out requests after the connect and read timeouts together
+		cfg.HttpClient = &rateLimitedOpenAPIClient{limiter: o.RateLimiter, timeout: 2 * o.Timeout}
+	}
+
+	cfg.RetryOptions = &dara.RetryOptions{
+		Retryable: true,
+		RetryCondition: []*dara.RetryCondition{
+			{
+				MaxAttempts: o.MaxAttempts,
+				ErrorCode:   o.retryableErrorCodes(),
+				// The OpenAPI client sleeps for the backoff delay in seconds (dara.Sleep)
+				MaxDelay: int(maxClientRetryDelay / time.Second),
+				Backoff:  openAPIBackoff{},
+			},
+		},
+	}
+}
+
+// applyToOSSConfig sets the timeouts, rate limit and retry policy on an OSS client config
+func (o clientOptions) applyToOSSConfig(cfg *oss.Config) {
+	cfg.WithConnectTimeout(o.Timeout)
+	cfg.WithReadWriteTimeout(o.Timeout)
+
+	if o.RateLimiter != nil {
+		// A custom HTTP client replaces the one the OSS client builds from the timeouts and proxy settings
+		client := ossTransport.NewHttpClient(&ossTransport.Config{
+			ConnectTimeout:   &o.Timeout,
+			ReadWriteTimeout: &o.Timeout,
+		}, ossTransport.ProxyFromEnvironment())
+		client.Transport = &rateLimitedTransport{limiter: o.RateLimiter, base: client.Transport}
+		cfg.WithHttpClient(client)
+	}
+
+	// Without "auto_retry", the HTTP status codes and connection errors the OSS client retries by default are not retried
+	errorRetryables := []ossRetry.ErrorRetryable{ossErrorCodeRetryable{codes: o.retryableErrorCodes()}}
+	if o.AutoRetry {
+		errorRetryables = append(slices.Clone(ossRetry.DefaultErrorRetryables), errorRetryables...)
+	}
+
+	cfg.WithRetryMaxAttempts(o.MaxAttempts)
+	cfg.WithRetryer(ossRetry.NewStandard(func(ro *ossRetry.RetryOptions) {
+		ro.MaxAttempts = o.MaxAttempts
+		ro.MaxBackoff = maxClientRetryDelay
+		ro.Backoff = ossBackoff{}
+		ro.ErrorRetryables = errorRetryables
+	}))
+}
+
+// applyToSLSClient sets the timeouts, rate limit and retry policy on an SLS client.
+// The SLS SDK retries connection errors and 5xx responses until a retry timeout elapses, on top of
+// any retries of its HTTP client. slsRetryTransport is the only retry layer instead: it retries them
+// for the configured number of attempts, with or without "auto_retry", and returns failures in a form
+// the SDK does not retry.
+func (o clientOptions) applyToSLSClient(client sls.ClientInterface) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   o.Timeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	// Bounds each attempt, as the timeout of the HTTP client spans all of them
+	transport.ResponseHeaderTimeout = o.Timeout
+
+	var roundTripper http.RoundTripper = transport
+	if o.RateLimiter != nil {
+		roundTripper = &rateLimitedTransport{limiter: o.RateLimiter, base: roundTripper}
+	}
+	roundTripper = &slsRetryTransport{base: roundTripper, attempts: o.MaxAttempts}
+
+	// Each attempt may take up to the dial and response header timeouts, and wait for a backoff delay before the next one
+	callTimeout := time.Duration(o.MaxAttempts)*2*o.Timeout + time.Duration(o.MaxAttempts-1)*maxClientRetryDelay
+	client.SetHTTPClient(&http.Client{
+		Transport: roundTripper,
+		Timeout:   callTimeout,
+	})
+	// The SDK retries a call which times out until its retry timeout, so it must not outlast the call
+	client.SetRetryTimeout(callTimeout)
+}
@@ -15,6 +15,11 @@ type alicloudCommonColumnData struct {
 
 // getCommonColumns:: helps to avoid multiple sts.GetCallerIdentity API calls in parallel where using it directly in column definitions
 func getCommonColumns(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Every table is queried per account, so the account is known without calling STS
+	if accountId := d.EqualsQualString(matrixKeyAccount); accountId != "" {
+		return &alicloudCommonColumnData{AccountID: accountId}, nil
+	}
+
 	getCallerIdentityData, err := getAccountDetails(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -28,21 +33,10 @@ func getCommonColumns(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	return commonColumnData, nil
 }
 
-func getAccountId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	getCallerIdentityData, err := getAccountDetails(ctx, d, h)
-	if err != nil {
-		return nil, err
-	}
-
-	callerIdentity := getCallerIdentityData.(*sts.GetCallerIdentityResponse)
-
-	return *callerIdentity.Body.AccountId, nil
-}
-
 var getAccountDetailsMemoize = plugin.HydrateFunc(getCallerIdentityUncached).Memoize(memoize.WithCacheKeyFunction(getAccountDetailsCacheKey))
 
 func getAccountDetailsCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	cacheKey := "GetCallerIdentity"
+	cacheKey := accountCacheKey(d, "GetCallerIdentity")
 	return cacheKey, nil
 }
 
@@ -65,7 +59,7 @@ func getCallerIdentityUncached(ctx context.Context, d *plugin.QueryData, _ *plug
 	if err != nil {
 		return nil, err
 	}
-	
+
 	callerIdentity, err := client.GetCallerIdentity()
 	if err != nil {
 		// let the cache know that we have failed to fetch this item
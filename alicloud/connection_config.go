@@ -2,6 +2,7 @@ package alicloud
 
 import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type alicloudConfig struct {
@@ -10,10 +11,37 @@ type alicloudConfig struct {
 	SecretKey        *string  `hcl:"secret_key"`
 	SessionToken     *string  `hcl:"session_token,optional"`
 	IgnoreErrorCodes []string `hcl:"ignore_error_codes,optional"`
-	Profile          *string  `hcl:"profile"`
-	AutoRetry        *bool    `hcl:"auto_retry,optional"`
-	MaxRetryTime     *int     `hcl:"max_retry_time,optional"`
-	Timeout          *int     `hcl:"timeout,optional"`
+	// IgnoreErrorRules is a list of objects with optional attributes, which HCL can only decode
+	// into a struct with all of its attributes set, so it is parsed by getIgnoreErrorRules
+	IgnoreErrorRules cty.Value `hcl:"ignore_error_rules,optional"`
+	Profile          *string   `hcl:"profile"`
+	AutoRetry        *bool     `hcl:"auto_retry,optional"`
+	MaxRetryTime     *int      `hcl:"max_retry_time,optional"`
+	Timeout          *int      `hcl:"timeout,optional"`
+
+	RateLimits map[string]float64 `hcl:"rate_limits,optional"`
+
+	Endpoints      map[string]string `hcl:"endpoints,optional"`
+	UseVpcEndpoint *bool             `hcl:"use_vpc_endpoint,optional"`
+
+	RoleArn         *string `hcl:"role_arn,optional"`
+	RoleSessionName *string `hcl:"role_session_name,optional"`
+	ExternalId      *string `hcl:"external_id,optional"`
+	SessionDuration *int    `hcl:"session_duration,optional"`
+	Policy          *string `hcl:"policy,optional"`
+
+	MemberAccounts []string `hcl:"member_accounts,optional"`
+	MemberRoleName *string  `hcl:"member_role_name,optional"`
+
+	RedactSensitiveColumns *bool `hcl:"redact_sensitive_columns,optional"`
+	CredentialReportMaxAge *int  `hcl:"credential_report_max_age,optional"`
+
+	CredentialSource *string `hcl:"credential_source,optional"`
+	ECSRAMRoleName   *string `hcl:"ecs_ram_role_name,optional"`
+	DisableIMDSv1    *bool   `hcl:"disable_imdsv1,optional"`
+	OIDCRoleArn      *string `hcl:"oidc_role_arn,optional"`
+	OIDCProviderArn  *string `hcl:"oidc_provider_arn,optional"`
+	OIDCTokenFile    *string `hcl:"oidc_token_file,optional"`
 }
 
 func ConfigInstance() interface{} {
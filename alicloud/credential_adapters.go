@@ -0,0 +1,72 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alibabacloud-go/tea/dara"
+
+	ossCred "github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	sls "github.com/aliyun/aliyun-log-go-sdk"
+	credential "github.com/aliyun/credentials-go/credentials"
+)
+
+// The OSS and SLS SDKs use their own credential provider interfaces instead of credentials-go.
+// The adapters below read the connection credential on every call, so STS tokens, assumed roles
+// and instance role credentials are refreshed by the underlying provider and never go stale
+// in cached OSS and SLS clients.
+
+// ossCredentialsProvider implements the OSS SDK CredentialsProvider interface on top of a credentials-go Credential
+type ossCredentialsProvider struct {
+	cred credential.Credential
+}
+
+func newOSSCredentialsProvider(cred credential.Credential) *ossCredentialsProvider {
+	return &ossCredentialsProvider{cred: cred}
+}
+
+func (p *ossCredentialsProvider) GetCredentials(_ context.Context) (ossCred.Credentials, error) {
+	model, err := getCredentialModel(p.cred)
+	if err != nil {
+		return ossCred.Credentials{}, err
+	}
+
+	return ossCred.Credentials{
+		AccessKeyID:     dara.StringValue(model.AccessKeyId),
+		AccessKeySecret: dara.StringValue(model.AccessKeySecret),
+		SecurityToken:   dara.StringValue(model.SecurityToken),
+	}, nil
+}
+
+// slsCredentialsProvider implements the SLS SDK CredentialsProvider interface on top of a credentials-go Credential
+type slsCredentialsProvider struct {
+	cred credential.Credential
+}
+
+func newSLSCredentialsProvider(cred credential.Credential) *slsCredentialsProvider {
+	return &slsCredentialsProvider{cred: cred}
+}
+
+func (p *slsCredentialsProvider) GetCredentials() (sls.Credentials, error) {
+	model, err := getCredentialModel(p.cred)
+	if err != nil {
+		return sls.Credentials{}, err
+	}
+
+	return sls.Credentials{
+		AccessKeyID:     dara.StringValue(model.AccessKeyId),
+		AccessKeySecret: dara.StringValue(model.AccessKeySecret),
+		SecurityToken:   dara.StringValue(model.SecurityToken),
+	}, nil
+}
+
+func getCredentialModel(cred credential.Credential) (*credential.CredentialModel, error) {
+	model, err := cred.GetCredential()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %v", err)
+	}
+	if model == nil || dara.StringValue(model.AccessKeyId) == "" || dara.StringValue(model.AccessKeySecret) == "" {
+		return nil, fmt.Errorf("failed to get credentials: credential provider returned no access key")
+	}
+	return model, nil
+}
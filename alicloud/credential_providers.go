@@ -0,0 +1,323 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+
+	sts "github.com/alibabacloud-go/sts-20150401/v2/client"
+	credentialProviders "github.com/aliyun/credentials-go/credentials/providers"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const (
+	// Values of the "credential_source" connection config argument
+	credentialSourceECSRAMRole = "ecs_ram_role"
+	credentialSourceOIDC       = "oidc"
+
+	// Provider name of assumed role credentials, as named by credentials-go
+	credentialSourceRAMRoleArn = "ram_role_arn"
+
+	// Temporary credentials are refreshed this long before they expire
+	credentialRefreshWindow = 3 * time.Minute
+
+	// The metadata service is link-local, so a short timeout also keeps probing for it cheap outside of ECS
+	ecsMetadataTimeout = time.Second
+
+	// Lifetime requested for ECS metadata service session tokens
+	ecsMetadataTokenTTL = 6 * time.Hour
+
+	// Lifetime requested for credentials from STS AssumeRole by default, and the shortest one STS accepts
+	defaultAssumeRoleDuration = 3600
+	minAssumeRoleDuration     = 900
+
+	// Lifetime requested for credentials from STS AssumeRoleWithOIDC
+	oidcSessionDuration = time.Hour
+
+	// Layout of the "Expiration" fields returned by the metadata service and STS
+	credentialExpirationLayout = "2006-01-02T15:04:05Z"
+)
+
+// refreshingCredentialsProvider caches the temporary credentials returned by fetch
+// and fetches new ones shortly before they expire.
+// It implements the credentials-go CredentialsProvider interface.
+type refreshingCredentialsProvider struct {
+	name  string
+	fetch func() (*credentialProviders.Credentials, time.Time, error)
+
+	mu         sync.Mutex
+	creds      *credentialProviders.Credentials
+	expiration time.Time
+}
+
+func newRefreshingCredentialsProvider(name string, fetch func() (*credentialProviders.Credentials, time.Time, error)) *refreshingCredentialsProvider {
+	return &refreshingCredentialsProvider{name: name, fetch: fetch}
+}
+
+func (p *refreshingCredentialsProvider) GetCredentials() (*credentialProviders.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.creds == nil || time.Until(p.expiration) <= credentialRefreshWindow {
+		creds, expiration, err := p.fetch()
+		if err != nil {
+			return nil, err
+		}
+		creds.ProviderName = p.name
+		p.creds = creds
+		p.expiration = expiration
+	}
+
+	// Return a copy so callers can not modify the cached credentials
+	creds := *p.creds
+	return &creds, nil
+}
+
+func (p *refreshingCredentialsProvider) GetProviderName() string {
+	return p.name
+}
+
+// synchronizedCredentialsProvider serializes calls to a provider which is not safe for concurrent use.
+// The provider of a connection is shared by all of its service clients.
+type synchronizedCredentialsProvider struct {
+	mu       sync.Mutex
+	provider credentialProviders.CredentialsProvider
+}
+
+func (p *synchronizedCredentialsProvider) GetCredentials() (*credentialProviders.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.provider.GetCredentials()
+}
+
+func (p *synchronizedCredentialsProvider) GetProviderName() string {
+	return p.provider.GetProviderName()
+}
+
+// ecsMetadataCredentials is the response of the ECS metadata service for a RAM role
+type ecsMetadataCredentials struct {
+	Code            string `json:"Code"`
+	AccessKeyId     string `json:"AccessKeyId"`
+	AccessKeySecret string `json:"AccessKeySecret"`
+	SecurityToken   string `json:"SecurityToken"`
+	Expiration      string `json:"Expiration"`
+}
+
+// ecsMetadataClient reads RAM role credentials from the ECS instance metadata service.
+// A session token is requested first (IMDSv2 style hardening) and sent with every metadata request.
+type ecsMetadataClient struct {
+	endpoint      serviceEndpoint
+	roleName      string
+	disableIMDSv1 bool
+	httpClient    *http.Client
+}
+
+// getECSRAMRoleCredentialsProvider returns a provider for the RAM role attached to the ECS instance
+func getECSRAMRoleCredentialsProvider(d *plugin.QueryData) (credentialProviders.CredentialsProvider, error) {
+	alicloudConfig := GetConfig(d.Connection)
+
+	endpoint, err := getServiceEndpoint(d.Connection, "ecs_metadata", "")
+	if err != nil {
+		return nil, err
+	}
+
+	client := &ecsMetadataClient{
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: ecsMetadataTimeout},
+	}
+	if alicloudConfig.ECSRAMRoleName != nil {
+		client.roleName = *alicloudConfig.ECSRAMRoleName
+	} else {
+		client.roleName = os.Getenv("ALIBABA_CLOUD_ECS_METADATA")
+	}
+	if alicloudConfig.DisableIMDSv1 != nil {
+		client.disableIMDSv1 = *alicloudConfig.DisableIMDSv1
+	}
+
+	return newRefreshingCredentialsProvider(credentialSourceECSRAMRole, client.getCredentials), nil
+}
+
+func (c *ecsMetadataClient) getCredentials() (*credentialProviders.Credentials, time.Time, error) {
+	token, err := c.getToken()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	roleName := c.roleName
+	if roleName == "" {
+		body, err := c.get("/latest/meta-data/ram/security-credentials/", token)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to get the RAM role name of the ECS instance: %v", err)
+		}
+		roleName = strings.TrimSpace(string(body))
+		if roleName == "" {
+			return nil, time.Time{}, fmt.Errorf("no RAM role is attached to the ECS instance")
+		}
+	}
+
+	body, err := c.get("/latest/meta-data/ram/security-credentials/"+roleName, token)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get credentials for ECS RAM role %s: %v", roleName, err)
+	}
+
+	var data ecsMetadataCredentials
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse credentials for ECS RAM role %s: %v", roleName, err)
+	}
+	if data.Code != "Success" || data.AccessKeyId == "" || data.AccessKeySecret == "" || data.SecurityToken == "" {
+		return nil, time.Time{}, fmt.Errorf("metadata service returned no credentials for ECS RAM role %s, code: %s", roleName, data.Code)
+	}
+
+	expiration, err := time.Parse(credentialExpirationLayout, data.Expiration)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse expiration of ECS RAM role %s credentials: %v", roleName, err)
+	}
+
+	return &credentialProviders.Credentials{
+		AccessKeyId:     data.AccessKeyId,
+		AccessKeySecret: data.AccessKeySecret,
+		SecurityToken:   data.SecurityToken,
+	}, expiration, nil
+}
+
+// getToken requests a metadata session token. If the token can not be retrieved, an empty
+// token is returned so the request falls back to IMDSv1, unless IMDSv1 is disabled.
+func (c *ecsMetadataClient) getToken() (string, error) {
+	req, err := http.NewRequest(http.MethodPut, c.endpoint.URL()+"/latest/api/token", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-aliyun-ecs-metadata-token-ttl-seconds", fmt.Sprint(int(ecsMetadataTokenTTL.Seconds())))
+
+	token, err := c.do(req)
+	if err != nil {
+		if c.disableIMDSv1 {
+			return "", fmt.Errorf("failed to get ECS metadata token: %v", err)
+		}
+		return "", nil
+	}
+
+	return string(token), nil
+}
+
+func (c *ecsMetadataClient) get(path string, token string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, c.endpoint.URL()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-aliyun-ecs-metadata-token", token)
+	}
+
+	return c.do(req)
+}
+
+func (c *ecsMetadataClient) do(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s returned status %d", req.Method, req.URL.Path, resp.StatusCode)
+	}
+
+	return body, nil
+}
+
+// oidcCredentialsConfig holds the settings for STS AssumeRoleWithOIDC as injected into ACK RRSA pods
+type oidcCredentialsConfig struct {
+	RoleArn         string
+	ProviderArn     string
+	TokenFile       string
+	RoleSessionName string
+}
+
+// getOIDCCredentialsProvider returns a provider which exchanges the OIDC token of the pod for STS credentials.
+// The token file is read again on every refresh, since it is rotated by the cluster.
+func getOIDCCredentialsProvider(ctx context.Context, d *plugin.QueryData) (credentialProviders.CredentialsProvider, error) {
+	alicloudConfig := GetConfig(d.Connection)
+	defaultRegion := GetDefaultRegion(d.Connection)
+
+	cfg := oidcCredentialsConfig{
+		RoleArn:         stringValueOrEnv(alicloudConfig.OIDCRoleArn, "ALIBABA_CLOUD_ROLE_ARN"),
+		ProviderArn:     stringValueOrEnv(alicloudConfig.OIDCProviderArn, "ALIBABA_CLOUD_OIDC_PROVIDER_ARN"),
+		TokenFile:       stringValueOrEnv(alicloudConfig.OIDCTokenFile, "ALIBABA_CLOUD_OIDC_TOKEN_FILE"),
+		RoleSessionName: os.Getenv("ALIBABA_CLOUD_ROLE_SESSION_NAME"),
+	}
+	if cfg.RoleArn == "" || cfg.ProviderArn == "" || cfg.TokenFile == "" {
+		return nil, fmt.Errorf("credential_source \"oidc\" requires a role ARN, an OIDC provider ARN and an OIDC token file, set them in the connection config or with ALIBABA_CLOUD_ROLE_ARN, ALIBABA_CLOUD_OIDC_PROVIDER_ARN and ALIBABA_CLOUD_OIDC_TOKEN_FILE")
+	}
+	if cfg.RoleSessionName == "" {
+		cfg.RoleSessionName = fmt.Sprintf("steampipe-%d", time.Now().Unix())
+	}
+
+	// AssumeRoleWithOIDC is an anonymous API, so the client is created without a credential
+	clientCfg, err := newOpenAPIConfig(d, "sts", nil, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+	client, err := sts.NewClient(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	fetch := func() (*credentialProviders.Credentials, time.Time, error) {
+		token, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to read OIDC token file: %v", err)
+		}
+
+		request := &sts.AssumeRoleWithOIDCRequest{
+			RoleArn:         dara.String(cfg.RoleArn),
+			OIDCProviderArn: dara.String(cfg.ProviderArn),
+			OIDCToken:       dara.String(strings.TrimSpace(string(token))),
+			RoleSessionName: dara.String(cfg.RoleSessionName),
+			DurationSeconds: dara.Int64(int64(oidcSessionDuration / time.Second)),
+		}
+		response, err := client.AssumeRoleWithOIDC(request)
+		if err != nil {
+			plugin.Logger(ctx).Error("getOIDCCredentialsProvider", "assume_role_with_oidc_error", err)
+			return nil, time.Time{}, err
+		}
+		if response.Body == nil || response.Body.Credentials == nil {
+			return nil, time.Time{}, fmt.Errorf("AssumeRoleWithOIDC returned no credentials for %s", cfg.RoleArn)
+		}
+
+		creds := response.Body.Credentials
+		expiration, err := time.Parse(credentialExpirationLayout, dara.StringValue(creds.Expiration))
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to parse expiration of OIDC credentials: %v", err)
+		}
+
+		return &credentialProviders.Credentials{
+			AccessKeyId:     dara.StringValue(creds.AccessKeyId),
+			AccessKeySecret: dara.StringValue(creds.AccessKeySecret),
+			SecurityToken:   dara.StringValue(creds.SecurityToken),
+		}, expiration, nil
+	}
+
+	return newRefreshingCredentialsProvider(credentialSourceOIDC, fetch), nil
+}
+
+// stringValueOrEnv returns the config value if set, otherwise the value of the environment variable
+func stringValueOrEnv(value *string, env string) string {
+	if value != nil {
+		return *value
+	}
+	return os.Getenv(env)
+}
@@ -0,0 +1,289 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+)
+
+// credentialTestContext returns a context with the logger the plugin functions expect
+func credentialTestContext() context.Context {
+	return context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+}
+
+// newCredentialTestQueryData returns query data for a connection with the config, which is enough
+// for the credential providers: they only read the connection config and endpoints
+func newCredentialTestQueryData(config alicloudConfig) *plugin.QueryData {
+	name := fmt.Sprintf("alicloud_credential_test_%d", testConnectionCount.Add(1))
+	return &plugin.QueryData{Connection: &plugin.Connection{Name: name, Config: config}}
+}
+
+// metadataStub is a local stand-in for the ECS metadata service
+type metadataStub struct {
+	// tokenStatus is the status of token requests, 200 issues a token
+	tokenStatus int
+	// roleName is the RAM role attached to the instance, empty for none
+	roleName string
+
+	mu       sync.Mutex
+	requests []string
+}
+
+const metadataStubToken = "stub-metadata-token"
+
+func newMetadataStub(t *testing.T, tokenStatus int, roleName string) (*metadataStub, *httptest.Server) {
+	t.Helper()
+
+	stub := &metadataStub{tokenStatus: tokenStatus, roleName: roleName}
+	server := httptest.NewServer(http.HandlerFunc(stub.serve))
+	t.Cleanup(server.Close)
+	return stub, server
+}
+
+func (s *metadataStub) serve(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-aliyun-ecs-metadata-token")
+	s.mu.Lock()
+	s.requests = append(s.requests, fmt.Sprintf("%s %s token=%t", r.Method, r.URL.Path, token != ""))
+	s.mu.Unlock()
+
+	if r.Method == http.MethodPut && r.URL.Path == "/latest/api/token" {
+		if r.Header.Get("X-aliyun-ecs-metadata-token-ttl-seconds") == "" {
+			http.Error(w, "missing token TTL", http.StatusBadRequest)
+			return
+		}
+		if s.tokenStatus != http.StatusOK {
+			w.WriteHeader(s.tokenStatus)
+			return
+		}
+		fmt.Fprint(w, metadataStubToken)
+		return
+	}
+
+	// Tokens are only checked when the service issues them, as IMDSv1 requests do not have one
+	if s.tokenStatus == http.StatusOK && token != metadataStubToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/latest/meta-data/ram/security-credentials/":
+		fmt.Fprint(w, s.roleName)
+	case "/latest/meta-data/ram/security-credentials/" + s.roleName:
+		_ = json.NewEncoder(w).Encode(ecsMetadataCredentials{
+			Code:            "Success",
+			AccessKeyId:     "STS.ecs-access-key",
+			AccessKeySecret: "ecs-access-key-secret",
+			SecurityToken:   "ecs-security-token",
+			Expiration:      time.Now().Add(time.Hour).UTC().Format(credentialExpirationLayout),
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *metadataStub) requestLog() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func TestECSRAMRoleCredentials(t *testing.T) {
+	cases := []struct {
+		name          string
+		tokenStatus   int
+		attachedRole  string
+		roleName      string
+		disableIMDSv1 bool
+		// requests are the metadata requests expected, an empty error expects credentials
+		requests []string
+		err      string
+	}{
+		{
+			name:         "discovers the role with a session token",
+			tokenStatus:  http.StatusOK,
+			attachedRole: "steampipe-role",
+			requests: []string{
+				"PUT /latest/api/token token=false",
+				"GET /latest/meta-data/ram/security-credentials/ token=true",
+				"GET /latest/meta-data/ram/security-credentials/steampipe-role token=true",
+			},
+		},
+		{
+			name:         "uses the configured role name",
+			tokenStatus:  http.StatusOK,
+			attachedRole: "steampipe-role",
+			roleName:     "steampipe-role",
+			requests: []string{
+				"PUT /latest/api/token token=false",
+				"GET /latest/meta-data/ram/security-credentials/steampipe-role token=true",
+			},
+		},
+		{
+			name:         "falls back to IMDSv1 without a session token",
+			tokenStatus:  http.StatusForbidden,
+			attachedRole: "steampipe-role",
+			requests: []string{
+				"PUT /latest/api/token token=false",
+				"GET /latest/meta-data/ram/security-credentials/ token=false",
+				"GET /latest/meta-data/ram/security-credentials/steampipe-role token=false",
+			},
+		},
+		{
+			name:          "disable_imdsv1 rejects the fallback",
+			tokenStatus:   http.StatusForbidden,
+			attachedRole:  "steampipe-role",
+			disableIMDSv1: true,
+			requests:      []string{"PUT /latest/api/token token=false"},
+			err:           "failed to get ECS metadata token: PUT /latest/api/token returned status 403",
+		},
+		{
+			name:        "fails without an attached role",
+			tokenStatus: http.StatusOK,
+			requests: []string{
+				"PUT /latest/api/token token=false",
+				"GET /latest/meta-data/ram/security-credentials/ token=true",
+			},
+			err: "no RAM role is attached to the ECS instance",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ALIBABA_CLOUD_ECS_METADATA", "")
+			stub, server := newMetadataStub(t, tc.tokenStatus, tc.attachedRole)
+
+			config := alicloudConfig{
+				Endpoints:     map[string]string{"ecs_metadata": server.URL},
+				DisableIMDSv1: &tc.disableIMDSv1,
+			}
+			if tc.roleName != "" {
+				config.ECSRAMRoleName = &tc.roleName
+			}
+
+			provider, err := getECSRAMRoleCredentialsProvider(newCredentialTestQueryData(config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			creds, err := provider.GetCredentials()
+
+			switch {
+			case tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)):
+				t.Errorf("expected error containing %q, got %v", tc.err, err)
+			case tc.err == "" && err != nil:
+				t.Errorf("failed to get credentials: %v", err)
+			case tc.err == "" && (creds.AccessKeyId != "STS.ecs-access-key" || creds.SecurityToken != "ecs-security-token" || creds.ProviderName != credentialSourceECSRAMRole):
+				t.Errorf("unexpected credentials %+v", creds)
+			}
+
+			if requests := stub.requestLog(); strings.Join(requests, "\n") != strings.Join(tc.requests, "\n") {
+				t.Errorf("unexpected metadata requests\nwant:\n  %s\ngot:\n  %s", strings.Join(tc.requests, "\n  "), strings.Join(requests, "\n  "))
+			}
+		})
+	}
+}
+
+// stsOIDCStub answers AssumeRoleWithOIDC, which is anonymous, so it is not signed like the requests the OpenAPI stub verifies
+type stsOIDCStub struct {
+	mu     sync.Mutex
+	tokens []string
+}
+
+func (s *stsOIDCStub) serve(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Header.Get("x-acs-action") != "AssumeRoleWithOIDC" {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("RoleArn") != "acs:ram::1234567890123456:role/ack-pod" || r.Form.Get("OIDCProviderArn") != "acs:ram::1234567890123456:oidc-provider/ack-rrsa" {
+		http.Error(w, "unexpected role or provider: "+r.Form.Encode(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.tokens = append(s.tokens, r.Form.Get("OIDCToken"))
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"Credentials": map[string]string{
+			"AccessKeyId":     "STS.oidc-access-key",
+			"AccessKeySecret": "oidc-access-key-secret",
+			"SecurityToken":   "oidc-security-token",
+			// Within the refresh window, so every call exchanges the token again
+			"Expiration": time.Now().Add(time.Minute).UTC().Format(credentialExpirationLayout),
+		},
+		"RequestId": "stub",
+	})
+}
+
+func TestOIDCCredentialsExchangeTokenFile(t *testing.T) {
+	for _, name := range []string{"ALIBABA_CLOUD_ROLE_ARN", "ALIBABA_CLOUD_OIDC_PROVIDER_ARN", "ALIBABA_CLOUD_OIDC_TOKEN_FILE", "ALIBABA_CLOUD_ROLE_SESSION_NAME"} {
+		t.Setenv(name, "")
+	}
+
+	stub := &stsOIDCStub{}
+	server := httptest.NewServer(http.HandlerFunc(stub.serve))
+	t.Cleanup(server.Close)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("first-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	roleArn, providerArn := "acs:ram::1234567890123456:role/ack-pod", "acs:ram::1234567890123456:oidc-provider/ack-rrsa"
+	d := newCredentialTestQueryData(alicloudConfig{
+		Endpoints:       map[string]string{"sts": server.URL},
+		OIDCRoleArn:     &roleArn,
+		OIDCProviderArn: &providerArn,
+		OIDCTokenFile:   &tokenFile,
+	})
+
+	provider, err := getOIDCCredentialsProvider(credentialTestContext(), d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds, err := provider.GetCredentials()
+	if err != nil {
+		t.Fatalf("failed to get credentials: %v", err)
+	}
+	if creds.AccessKeyId != "STS.oidc-access-key" || creds.SecurityToken != "oidc-security-token" || creds.ProviderName != credentialSourceOIDC {
+		t.Errorf("unexpected credentials %+v", creds)
+	}
+
+	// The token file is rotated by the cluster, and read again on refresh
+	if err := os.WriteFile(tokenFile, []byte("second-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.GetCredentials(); err != nil {
+		t.Fatalf("failed to refresh credentials: %v", err)
+	}
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	if strings.Join(stub.tokens, ",") != "first-token,second-token" {
+		t.Errorf("unexpected OIDC tokens exchanged: %v", stub.tokens)
+	}
+}
+
+func TestOIDCCredentialsRequireRoleProviderAndTokenFile(t *testing.T) {
+	for _, name := range []string{"ALIBABA_CLOUD_ROLE_ARN", "ALIBABA_CLOUD_OIDC_PROVIDER_ARN", "ALIBABA_CLOUD_OIDC_TOKEN_FILE"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("ALIBABA_CLOUD_ROLE_ARN", "acs:ram::1234567890123456:role/ack-pod")
+
+	_, err := getOIDCCredentialsProvider(credentialTestContext(), newCredentialTestQueryData(alicloudConfig{}))
+	if err == nil || !strings.Contains(err.Error(), "requires a role ARN, an OIDC provider ARN and an OIDC token file") {
+		t.Errorf("expected an error for the missing OIDC settings, got %v", err)
+	}
+}
@@ -0,0 +1,359 @@
+package alicloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+
+	sts "github.com/alibabacloud-go/sts-20150401/v2/client"
+	credential "github.com/aliyun/credentials-go/credentials"
+	credentialProviders "github.com/aliyun/credentials-go/credentials/providers"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Steps of the credential resolution chain, in order of precedence.
+// See credentialResolutionChain for details.
+const (
+	credentialStepConfig       = "config"
+	credentialStepEnv          = "env"
+	credentialStepProfile      = "profile"
+	credentialStepDefaultChain = "default_chain"
+	credentialStepInstanceRole = "instance_role"
+)
+
+// Environment variables for static credentials and profiles, checked in order.
+// https://github.com/aliyun/aliyun-cli/blob/master/README.md#supported-environment-variables
+var (
+	accessKeyIdEnvVars     = []string{"ALIBABACLOUD_ACCESS_KEY_ID", "ALIBABA_CLOUD_ACCESS_KEY_ID", "ALICLOUD_ACCESS_KEY_ID", "ALICLOUD_ACCESS_KEY"}
+	accessKeySecretEnvVars = []string{"ALIBABACLOUD_ACCESS_KEY_SECRET", "ALIBABA_CLOUD_ACCESS_KEY_SECRET", "ALICLOUD_ACCESS_KEY_SECRET", "ALICLOUD_SECRET_KEY"}
+	securityTokenEnvVars   = []string{"ALIBABACLOUD_SECURITY_TOKEN", "ALIBABA_CLOUD_SECURITY_TOKEN", "ALICLOUD_SECURITY_TOKEN", "ALICLOUD_SESSION_TOKEN"}
+	profileEnvVars         = []string{"ALIBABACLOUD_PROFILE", "ALIBABA_CLOUD_PROFILE", "ALICLOUD_PROFILE"}
+)
+
+// errNoCredentials is returned when none of the steps of the credential resolution chain found credentials
+var errNoCredentials = errors.New("no credentials found. Set 'access_key' and 'secret_key', 'profile' or 'credential_source' in the connection configuration, or set credentials in environment variables. Edit your connection configuration file and then restart Steampipe")
+
+// credentialError is returned when a step of the credential resolution chain applies to the connection but fails
+type credentialError struct {
+	Step string
+	Err  error
+}
+
+func (e *credentialError) Error() string {
+	return fmt.Sprintf("failed to resolve credentials from %s: %v", e.Step, e.Err)
+}
+
+func (e *credentialError) Unwrap() error {
+	return e.Err
+}
+
+// credentialResolver returns the credentials provider of one step of the chain, or nil if the step does not apply
+type credentialResolver func(ctx context.Context, d *plugin.QueryData) (credentialProviders.CredentialsProvider, error)
+
+// credentialStep is a named step of the credential resolution chain
+type credentialStep struct {
+	Name    string
+	Resolve credentialResolver
+}
+
+// credentialResolutionChain returns the steps used to resolve credentials, in order of precedence:
+//  1. config: "credential_source", "profile" or "access_key"/"secret_key"/"session_token" in the connection config.
+//  2. env: static credentials in the ALIBABACLOUD_*, ALIBABA_CLOUD_* or ALICLOUD_* environment variables.
+//  3. profile: a named Aliyun CLI profile from the ALIBABACLOUD_PROFILE, ALIBABA_CLOUD_PROFILE or ALICLOUD_PROFILE environment variables.
+//  4. default_chain: OIDC (ALIBABA_CLOUD_OIDC_TOKEN_FILE), the current Aliyun CLI profile or the ~/.alibabacloud/credentials file.
+//  5. instance_role: the RAM role of the ECS instance, unless ALIBABA_CLOUD_ECS_METADATA_DISABLED is set to true.
+func credentialResolutionChain() []credentialStep {
+	return []credentialStep{
+		{credentialStepConfig, getConfigCredentialsProvider},
+		{credentialStepEnv, getEnvCredentialsProvider},
+		{credentialStepProfile, getEnvProfileCredentialsProvider},
+		{credentialStepDefaultChain, getDefaultChainCredentialsProvider},
+		{credentialStepInstanceRole, getInstanceRoleCredentialsProvider},
+	}
+}
+
+var getCredentialSessionCached = plugin.HydrateFunc(getCredentialSessionUncached).Memoize()
+
+func getCredentialSessionUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	config := GetConfig(d.Connection)
+	defaultRegion := GetDefaultRegion(d.Connection)
+
+	for _, step := range credentialResolutionChain() {
+		provider, err := step.Resolve(ctx, d)
+		if err != nil {
+			plugin.Logger(ctx).Error("getCredentialSessionUncached", "credential_step", step.Name, "error", err)
+			return nil, &credentialError{Step: step.Name, Err: err}
+		}
+		if provider == nil {
+			continue
+		}
+
+		// Assume role on top of the base credentials
+		if config.RoleArn != nil {
+			provider, err = getAssumeRoleCredentialsProvider(d, provider)
+			if err != nil {
+				return nil, &credentialError{Step: step.Name, Err: err}
+			}
+		}
+
+		plugin.Logger(ctx).Info("getCredentialSessionUncached", "credential_step", step.Name, "provider", provider.GetProviderName())
+		provider = &synchronizedCredentialsProvider{provider: provider}
+		cred := credential.FromCredentialsProvider(provider.GetProviderName(), provider)
+		return &CredentialConfig{Cred: cred, Provider: provider, DefaultRegion: defaultRegion, Source: step.Name}, nil
+	}
+
+	return nil, errNoCredentials
+}
+
+// getConfigCredentialsProvider returns the credentials provider for the credential source, profile or static credentials of the connection config
+func getConfigCredentialsProvider(ctx context.Context, d *plugin.QueryData) (credentialProviders.CredentialsProvider, error) {
+	config := GetConfig(d.Connection)
+
+	// Credentials which are not configured in the connection itself
+	if config.CredentialSource != nil {
+		switch *config.CredentialSource {
+		case credentialSourceECSRAMRole:
+			return getECSRAMRoleCredentialsProvider(d)
+		case credentialSourceOIDC:
+			return getOIDCCredentialsProvider(ctx, d)
+		default:
+			return nil, fmt.Errorf("invalid value for \"credential_source\": %s, it must be one of %s or %s", *config.CredentialSource, credentialSourceECSRAMRole, credentialSourceOIDC)
+		}
+	}
+
+	if config.Profile != nil {
+		return getCredentialsProviderByProfile(*config.Profile)
+	}
+
+	accessKey, secretKey, sessionToken := dara.StringValue(config.AccessKey), dara.StringValue(config.SecretKey), dara.StringValue(config.SessionToken)
+	if accessKey == "" && secretKey == "" {
+		if sessionToken != "" {
+			return nil, errors.New("'session_token' is set without 'access_key' and 'secret_key'")
+		}
+		return nil, nil
+	}
+	if accessKey == "" {
+		return nil, errors.New("'secret_key' is set without 'access_key'")
+	}
+	if secretKey == "" {
+		return nil, errors.New("'access_key' is set without 'secret_key'")
+	}
+
+	return getStaticCredentialsProvider(accessKey, secretKey, sessionToken)
+}
+
+// getEnvCredentialsProvider returns the credentials provider for static credentials set in environment variables
+func getEnvCredentialsProvider(_ context.Context, _ *plugin.QueryData) (credentialProviders.CredentialsProvider, error) {
+	accessKey, accessKeyEnv := lookupFirstEnv(accessKeyIdEnvVars)
+	secretKey, secretKeyEnv := lookupFirstEnv(accessKeySecretEnvVars)
+	sessionToken, _ := lookupFirstEnv(securityTokenEnvVars)
+
+	if accessKey == "" && secretKey == "" {
+		return nil, nil
+	}
+	if accessKey == "" {
+		return nil, fmt.Errorf("%s is set without an access key ID, set one of %s", secretKeyEnv, strings.Join(accessKeyIdEnvVars, ", "))
+	}
+	if secretKey == "" {
+		return nil, fmt.Errorf("%s is set without an access key secret, set one of %s", accessKeyEnv, strings.Join(accessKeySecretEnvVars, ", "))
+	}
+
+	return getStaticCredentialsProvider(accessKey, secretKey, sessionToken)
+}
+
+// getEnvProfileCredentialsProvider returns the credentials provider for the Aliyun CLI profile named in an environment variable
+func getEnvProfileCredentialsProvider(_ context.Context, _ *plugin.QueryData) (credentialProviders.CredentialsProvider, error) {
+	profile, _ := lookupFirstEnv(profileEnvVars)
+	if profile == "" {
+		return nil, nil
+	}
+	return getCredentialsProviderByProfile(profile)
+}
+
+// getDefaultChainCredentialsProvider returns the first provider of the default credential chain which returns credentials.
+// Unlike the earlier steps, the providers are probed since they depend on files and environment outside the connection config.
+func getDefaultChainCredentialsProvider(ctx context.Context, d *plugin.QueryData) (credentialProviders.CredentialsProvider, error) {
+	var candidates []credentialProviders.CredentialsProvider
+
+	if os.Getenv("ALIBABA_CLOUD_OIDC_TOKEN_FILE") != "" {
+		provider, err := getOIDCCredentialsProvider(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, provider)
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		if _, err := os.Stat(filepath.Join(home, ".aliyun", "config.json")); err == nil {
+			if provider, err := credentialProviders.NewCLIProfileCredentialsProviderBuilder().Build(); err == nil {
+				candidates = append(candidates, provider)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(home, ".alibabacloud", "credentials")); err == nil {
+			if provider, err := credentialProviders.NewProfileCredentialsProviderBuilder().Build(); err == nil {
+				candidates = append(candidates, provider)
+			}
+		}
+	}
+
+	for _, provider := range candidates {
+		if _, err := provider.GetCredentials(); err != nil {
+			plugin.Logger(ctx).Debug("getDefaultChainCredentialsProvider", "provider", provider.GetProviderName(), "error", err)
+			continue
+		}
+		return provider, nil
+	}
+
+	return nil, nil
+}
+
+// getInstanceRoleCredentialsProvider returns the credentials provider for the RAM role of the ECS instance, if Steampipe runs on one
+func getInstanceRoleCredentialsProvider(ctx context.Context, d *plugin.QueryData) (credentialProviders.CredentialsProvider, error) {
+	if strings.EqualFold(os.Getenv("ALIBABA_CLOUD_ECS_METADATA_DISABLED"), "true") {
+		return nil, nil
+	}
+
+	provider, err := getECSRAMRoleCredentialsProvider(d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Not running on ECS, or no role attached to the instance
+	if _, err := provider.GetCredentials(); err != nil {
+		plugin.Logger(ctx).Debug("getInstanceRoleCredentialsProvider", "error", err)
+		return nil, nil
+	}
+
+	return provider, nil
+}
+
+func getCredentialsProviderByProfile(profile string) (credentialProviders.CredentialsProvider, error) {
+	return credentialProviders.NewCLIProfileCredentialsProviderBuilder().
+		WithProfileName(profile).
+		Build()
+}
+
+func getStaticCredentialsProvider(accessKey string, secretKey string, sessionToken string) (credentialProviders.CredentialsProvider, error) {
+	if sessionToken != "" {
+		return credentialProviders.NewStaticSTSCredentialsProviderBuilder().
+			WithAccessKeyId(accessKey).
+			WithAccessKeySecret(secretKey).
+			WithSecurityToken(sessionToken).
+			Build()
+	}
+	return credentialProviders.NewStaticAKCredentialsProviderBuilder().
+		WithAccessKeyId(accessKey).
+		WithAccessKeySecret(secretKey).
+		Build()
+}
+
+// getAssumeRoleCredentialsProvider wraps the base credentials in a provider which calls STS AssumeRole
+// for the "role_arn" connection config argument. The assumed credentials are refreshed automatically
+// shortly before they expire.
+func getAssumeRoleCredentialsProvider(d *plugin.QueryData, base credentialProviders.CredentialsProvider) (credentialProviders.CredentialsProvider, error) {
+	alicloudConfig := GetConfig(d.Connection)
+
+	request := assumeRoleRequest{RoleArn: *alicloudConfig.RoleArn}
+	if alicloudConfig.RoleSessionName != nil {
+		request.RoleSessionName = *alicloudConfig.RoleSessionName
+	}
+	if alicloudConfig.ExternalId != nil {
+		request.ExternalId = *alicloudConfig.ExternalId
+	}
+	if alicloudConfig.SessionDuration != nil {
+		request.DurationSeconds = *alicloudConfig.SessionDuration
+	}
+	if alicloudConfig.Policy != nil {
+		request.Policy = *alicloudConfig.Policy
+	}
+
+	return newAssumeRoleCredentialsProvider(d, base, request)
+}
+
+// assumeRoleRequest holds the STS AssumeRole parameters of a role. Empty values use the defaults.
+type assumeRoleRequest struct {
+	RoleArn         string
+	RoleSessionName string
+	ExternalId      string
+	Policy          string
+	DurationSeconds int
+}
+
+// newAssumeRoleCredentialsProvider returns a provider which assumes the role with the base credentials.
+// STS is called through the OpenAPI client of the connection, so the "sts" endpoint override is used
+// with its protocol, e.g. a local stand-in over http, along with the retries and timeouts of the connection.
+func newAssumeRoleCredentialsProvider(d *plugin.QueryData, base credentialProviders.CredentialsProvider, request assumeRoleRequest) (credentialProviders.CredentialsProvider, error) {
+	if request.RoleSessionName == "" {
+		request.RoleSessionName = "steampipe-" + strconv.FormatInt(time.Now().Unix(), 10)
+	}
+	if request.DurationSeconds == 0 {
+		request.DurationSeconds = defaultAssumeRoleDuration
+	}
+	if request.DurationSeconds < minAssumeRoleDuration {
+		return nil, fmt.Errorf("failed to configure assume role for %s: session_duration must be at least %d seconds", request.RoleArn, minAssumeRoleDuration)
+	}
+
+	cred := credential.FromCredentialsProvider(base.GetProviderName(), base)
+	clientCfg, err := newOpenAPIConfig(d, "sts", cred, GetDefaultRegion(d.Connection))
+	if err != nil {
+		return nil, err
+	}
+	client, err := sts.NewClient(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	fetch := func() (*credentialProviders.Credentials, time.Time, error) {
+		stsRequest := &sts.AssumeRoleRequest{
+			RoleArn:         dara.String(request.RoleArn),
+			RoleSessionName: dara.String(request.RoleSessionName),
+			DurationSeconds: dara.Int64(int64(request.DurationSeconds)),
+		}
+		if request.ExternalId != "" {
+			stsRequest.ExternalId = dara.String(request.ExternalId)
+		}
+		if request.Policy != "" {
+			stsRequest.Policy = dara.String(request.Policy)
+		}
+
+		response, err := client.AssumeRole(stsRequest)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to assume role %s: %w", request.RoleArn, err)
+		}
+		if response.Body == nil || response.Body.Credentials == nil {
+			return nil, time.Time{}, fmt.Errorf("AssumeRole returned no credentials for %s", request.RoleArn)
+		}
+
+		creds := response.Body.Credentials
+		expiration, err := time.Parse(credentialExpirationLayout, dara.StringValue(creds.Expiration))
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to parse expiration of assumed role %s credentials: %v", request.RoleArn, err)
+		}
+
+		return &credentialProviders.Credentials{
+			AccessKeyId:     dara.StringValue(creds.AccessKeyId),
+			AccessKeySecret: dara.StringValue(creds.AccessKeySecret),
+			SecurityToken:   dara.StringValue(creds.SecurityToken),
+		}, expiration, nil
+	}
+
+	return newRefreshingCredentialsProvider(credentialSourceRAMRoleArn, fetch), nil
+}
+
+// lookupFirstEnv returns the value and name of the first environment variable which is set to a non-empty value
+func lookupFirstEnv(names []string) (value string, name string) {
+	for _, name := range names {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			return value, name
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,195 @@
+package alicloud
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// isolateCredentialEnv clears the environment the credential resolution chain reads,
+// and points the home directory at an empty directory, which is returned
+func isolateCredentialEnv(t *testing.T) string {
+	t.Helper()
+
+	var names []string
+	for _, vars := range [][]string{accessKeyIdEnvVars, accessKeySecretEnvVars, securityTokenEnvVars, profileEnvVars} {
+		names = append(names, vars...)
+	}
+	names = append(names,
+		"ALIBABA_CLOUD_CONFIG_FILE",
+		"ALIBABA_CLOUD_CREDENTIALS_FILE",
+		"ALIBABA_CLOUD_CLI_PROFILE_DISABLED",
+		"ALIBABA_CLOUD_OIDC_TOKEN_FILE",
+		"ALIBABA_CLOUD_ROLE_ARN",
+		"ALIBABA_CLOUD_ECS_METADATA",
+		"ALIBABA_CLOUD_ECS_METADATA_DISABLED",
+	)
+	for _, name := range names {
+		t.Setenv(name, "")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	return home
+}
+
+func writeCredentialFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCredentialResolutionChainPrecedence(t *testing.T) {
+	// Each step is set up along with the ones below it, so the step chosen is the one of highest precedence
+	cases := []struct {
+		step        string
+		accessKeyId string
+		setup       func(t *testing.T, home string, config *alicloudConfig)
+	}{
+		{
+			step:        credentialStepConfig,
+			accessKeyId: "LTAIconfig",
+			setup: func(t *testing.T, home string, config *alicloudConfig) {
+				accessKey, secretKey := "LTAIconfig", "config-secret"
+				config.AccessKey, config.SecretKey = &accessKey, &secretKey
+			},
+		},
+		{
+			step:        credentialStepEnv,
+			accessKeyId: "LTAIenv",
+			setup: func(t *testing.T, home string, config *alicloudConfig) {
+				t.Setenv("ALIBABA_CLOUD_ACCESS_KEY_ID", "LTAIenv")
+				t.Setenv("ALIBABA_CLOUD_ACCESS_KEY_SECRET", "env-secret")
+			},
+		},
+		{
+			step:        credentialStepProfile,
+			accessKeyId: "LTAIprofile",
+			setup: func(t *testing.T, home string, config *alicloudConfig) {
+				t.Setenv("ALIBABACLOUD_PROFILE", "audit")
+				writeCredentialFile(t, filepath.Join(home, ".aliyun", "config.json"), `{
+  "current": "default",
+  "profiles": [
+    {"name": "default", "mode": "AK", "access_key_id": "LTAIcurrent", "access_key_secret": "current-secret"},
+    {"name": "audit", "mode": "AK", "access_key_id": "LTAIprofile", "access_key_secret": "profile-secret"}
+  ]
+}`)
+			},
+		},
+		{
+			step:        credentialStepDefaultChain,
+			accessKeyId: "LTAIshared",
+			setup: func(t *testing.T, home string, config *alicloudConfig) {
+				writeCredentialFile(t, filepath.Join(home, ".alibabacloud", "credentials"), "[default]\ntype = access_key\naccess_key_id = LTAIshared\naccess_key_secret = shared-secret\n")
+			},
+		},
+		{
+			step:        credentialStepInstanceRole,
+			accessKeyId: "STS.ecs-access-key",
+			setup: func(t *testing.T, home string, config *alicloudConfig) {
+				_, server := newMetadataStub(t, http.StatusOK, "steampipe-role")
+				config.Endpoints = map[string]string{"ecs_metadata": server.URL}
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(tc.step, func(t *testing.T) {
+			home := isolateCredentialEnv(t)
+			config := alicloudConfig{}
+			for _, step := range cases[i:] {
+				step.setup(t, home, &config)
+			}
+
+			session, err := getCredentialSessionUncached(credentialTestContext(), newCredentialTestQueryData(config), nil)
+			if err != nil {
+				t.Fatalf("failed to resolve credentials: %v", err)
+			}
+			credConfig := session.(*CredentialConfig)
+			if credConfig.Source != tc.step {
+				t.Errorf("expected credentials from step %s, got %s", tc.step, credConfig.Source)
+			}
+			creds, err := credConfig.Provider.GetCredentials()
+			if err != nil {
+				t.Fatalf("failed to get credentials: %v", err)
+			}
+			if creds.AccessKeyId != tc.accessKeyId {
+				t.Errorf("expected access key %s, got %s", tc.accessKeyId, creds.AccessKeyId)
+			}
+		})
+	}
+}
+
+func TestCredentialResolutionChainErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		setup func(t *testing.T, config *alicloudConfig)
+		// step is the step of the credentialError, empty for errNoCredentials
+		step string
+		err  string
+	}{
+		{
+			name: "no step finds credentials",
+			setup: func(t *testing.T, config *alicloudConfig) {
+				// No RAM role is attached to the instance
+				_, server := newMetadataStub(t, http.StatusOK, "")
+				config.Endpoints = map[string]string{"ecs_metadata": server.URL}
+			},
+			err: errNoCredentials.Error(),
+		},
+		{
+			name: "config with a session token only",
+			setup: func(t *testing.T, config *alicloudConfig) {
+				token := "token"
+				config.SessionToken = &token
+			},
+			step: credentialStepConfig,
+			err:  "failed to resolve credentials from config: 'session_token' is set without 'access_key' and 'secret_key'",
+		},
+		{
+			name: "env with a secret only",
+			setup: func(t *testing.T, config *alicloudConfig) {
+				t.Setenv("ALICLOUD_SECRET_KEY", "env-secret")
+			},
+			step: credentialStepEnv,
+			err:  "failed to resolve credentials from env: ALICLOUD_SECRET_KEY is set without an access key ID",
+		},
+		{
+			name: "invalid credential source",
+			setup: func(t *testing.T, config *alicloudConfig) {
+				source := "instance"
+				config.CredentialSource = &source
+			},
+			step: credentialStepConfig,
+			err:  `failed to resolve credentials from config: invalid value for "credential_source": instance`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			isolateCredentialEnv(t)
+			config := alicloudConfig{}
+			tc.setup(t, &config)
+
+			_, err := getCredentialSessionUncached(credentialTestContext(), newCredentialTestQueryData(config), nil)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+
+			var credErr *credentialError
+			switch {
+			case tc.step == "" && !errors.Is(err, errNoCredentials):
+				t.Errorf("expected errNoCredentials, got %T", err)
+			case tc.step != "" && (!errors.As(err, &credErr) || credErr.Step != tc.step):
+				t.Errorf("expected a credentialError of step %s, got %#v", tc.step, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package alicloud
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// endpointRegionPlaceholder is replaced with the region of the client in endpoint templates
+const endpointRegionPlaceholder = "{region}"
+
+// serviceEndpointTemplate holds the default endpoints of a service.
+// An empty template means the endpoint is resolved by the SDK.
+type serviceEndpointTemplate struct {
+	Public string
+	VPC    string
+}
+
+// serviceEndpointTemplates is keyed by the service names accepted in the "endpoints" connection config argument.
+// VPC endpoints follow the "<product>-vpc.<region>.aliyuncs.com" convention unless the service documents otherwise.
+var serviceEndpointTemplates = map[string]serviceEndpointTemplate{
+	"actiontrail":     {VPC: "actiontrail-vpc.{region}.aliyuncs.com"},
+	"alidns":          {VPC: "alidns-vpc.{region}.aliyuncs.com"},
+	"cas":             {VPC: "cas-vpc.{region}.aliyuncs.com"},
+	"cms":             {VPC: "metrics-vpc.{region}.aliyuncs.com"},
+	"cs":              {VPC: "cs-vpc.{region}.aliyuncs.com"},
+	"ecs":             {VPC: "ecs-vpc.{region}.aliyuncs.com"},
+	"ecs_metadata":    {Public: "http://100.100.100.200", VPC: "http://100.100.100.200"},
+	"ess":             {VPC: "ess-vpc.{region}.aliyuncs.com"},
+	"fc":              {Public: "fcv3.{region}.aliyuncs.com", VPC: "fcv3-vpc.{region}.aliyuncs.com"},
+	"ims":             {VPC: "ims.vpc-proxy.aliyuncs.com"},
+	"kms":             {VPC: "kms-vpc.{region}.aliyuncs.com"},
+	"oss":             {Public: "oss-{region}.aliyuncs.com", VPC: "oss-{region}-internal.aliyuncs.com"},
+	"ram":             {VPC: "ram.vpc-proxy.aliyuncs.com"},
+	"rds":             {VPC: "rds-vpc.{region}.aliyuncs.com"},
+	"resourcemanager": {Public: "resourcemanager.aliyuncs.com", VPC: "resourcemanager.vpc-proxy.aliyuncs.com"},
+	"sae":             {VPC: "sae-vpc.{region}.aliyuncs.com"},
+	"sas":             {VPC: "sas-vpc.{region}.aliyuncs.com"},
+	"slb":             {VPC: "slb-vpc.{region}.aliyuncs.com"},
+	"sls":             {Public: "{region}.log.aliyuncs.com", VPC: "{region}-intranet.log.aliyuncs.com"},
+	"sts":             {VPC: "sts-vpc.{region}.aliyuncs.com"},
+	"vpc":             {VPC: "vpc-vpc.{region}.aliyuncs.com"},
+}
+
+// serviceEndpoint is the endpoint a service client connects to
+type serviceEndpoint struct {
+	// Host and optional port, without the scheme. Empty if the SDK should resolve the endpoint.
+	Host string
+	// "http" or "https" if set explicitly in the endpoint, empty to use the SDK default
+	Protocol string
+}
+
+// URL returns the endpoint including its scheme, if one was set
+func (e serviceEndpoint) URL() string {
+	if e.Protocol == "" {
+		return e.Host
+	}
+	return e.Protocol + "://" + e.Host
+}
+
+// getServiceEndpoint resolves the endpoint of a service in a region, in the following order:
+// 1. The "endpoints" connection config argument for the service.
+// 2. The VPC endpoint of the service, if "use_vpc_endpoint" is set.
+// 3. The default public endpoint of the service, if the plugin sets one.
+func getServiceEndpoint(connection *plugin.Connection, service string, region string) (serviceEndpoint, error) {
+	alicloudConfig := GetConfig(connection)
+
+	if err := validateServiceNames("endpoints", alicloudConfig.Endpoints); err != nil {
+		return serviceEndpoint{}, err
+	}
+
+	template := serviceEndpointTemplates[service]
+
+	endpoint := template.Public
+	if alicloudConfig.UseVpcEndpoint != nil && *alicloudConfig.UseVpcEndpoint {
+		endpoint = template.VPC
+	}
+	if override, ok := alicloudConfig.Endpoints[service]; ok {
+		endpoint = override
+	}
+
+	return parseServiceEndpoint(strings.ReplaceAll(endpoint, endpointRegionPlaceholder, region)), nil
+}
+
+// parseServiceEndpoint splits the scheme from an endpoint, e.g. "http://127.0.0.1:8080"
+func parseServiceEndpoint(endpoint string) serviceEndpoint {
+	endpoint = strings.TrimSuffix(strings.TrimSpace(endpoint), "/")
+	for _, protocol := range []string{"http", "https"} {
+		if host, ok := strings.CutPrefix(endpoint, protocol+"://"); ok {
+			return serviceEndpoint{Host: host, Protocol: protocol}
+		}
+	}
+	return serviceEndpoint{Host: endpoint}
+}
+
+// validateServiceNames returns an error for service names of a connection config argument the plugin does not know about
+func validateServiceNames[V any](argument string, services map[string]V) error {
+	var unknown []string
+	for service := range services {
+		if _, ok := serviceEndpointTemplates[service]; !ok {
+			unknown = append(unknown, service)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	valid := make([]string, 0, len(serviceEndpointTemplates))
+	for service := range serviceEndpointTemplates {
+		valid = append(valid, service)
+	}
+	slices.Sort(unknown)
+	slices.Sort(valid)
+
+	return fmt.Errorf("connection config has unknown services in \"%s\": %s. Valid services are: %s", argument, strings.Join(unknown, ", "), strings.Join(valid, ", "))
+}
@@ -0,0 +1,161 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/alibabacloud-go/tea/tea"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	sls "github.com/aliyun/aliyun-log-go-sdk"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// maxQueryDiagnostics bounds the ignored errors kept by the plugin process, the oldest are dropped first
+const maxQueryDiagnostics = 10000
+
+// queryDiagnostic is an error which was ignored during a query, so the query returned fewer rows
+// or columns instead of failing
+type queryDiagnostic struct {
+	Connection   string
+	AccountId    string
+	Table        string
+	Region       string
+	Service      string
+	Action       string
+	Reason       string
+	ErrorCode    string
+	StatusCode   int
+	RequestId    string
+	ErrorMessage string
+	Time         time.Time
+}
+
+// errorJournal keeps the most recent ignored errors of all connections of the plugin process
+type errorJournal struct {
+	mu sync.Mutex
+	// entries is a ring buffer, next is the index of the oldest entry once it is full
+	entries []queryDiagnostic
+	next    int
+}
+
+var queryDiagnostics = &errorJournal{}
+
+func (j *errorJournal) add(entry queryDiagnostic) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if len(j.entries) < maxQueryDiagnostics {
+		j.entries = append(j.entries, entry)
+		return
+	}
+	j.entries[j.next] = entry
+	j.next = (j.next + 1) % maxQueryDiagnostics
+}
+
+// list returns the ignored errors of a connection, oldest first
+func (j *errorJournal) list(connection string) []queryDiagnostic {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var entries []queryDiagnostic
+	for i := range j.entries {
+		entry := j.entries[(j.next+i)%len(j.entries)]
+		if entry.Connection == connection {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// recordIgnoredError logs an error swallowed by the plugin at debug level, and adds it to the
+// journal listed by the alicloud_query_diagnostic table
+func recordIgnoredError(ctx context.Context, d *plugin.QueryData, err error, reason string) {
+	details := getAPIErrorDetails(err)
+	tags := hydrateTags(d, hydrateName(ctx))
+
+	entry := queryDiagnostic{
+		Connection:   d.Connection.Name,
+		AccountId:    d.EqualsQualString(matrixKeyAccount),
+		Table:        d.Table.Name,
+		Region:       d.EqualsQualString(matrixKeyRegion),
+		Service:      tags["service"],
+		Action:       tags["action"],
+		Reason:       reason,
+		ErrorCode:    details.Code,
+		StatusCode:   details.StatusCode,
+		RequestId:    details.RequestId,
+		ErrorMessage: err.Error(),
+		Time:         time.Now(),
+	}
+	if entry.Service == "" {
+		entry.Service = tableService(d)
+	}
+
+	plugin.Logger(ctx).Debug("ignoring error", "table", entry.Table, "connection", entry.Connection, "region", entry.Region, "action", entry.Action, "reason", reason, "error", err)
+	queryDiagnostics.add(entry)
+}
+
+// hydrateTags returns the tags of the config of a hydrate function of the table, by the name of the function,
+// which nameHydrateIgnoreConfigs passes to the ignore predicates. The tags are empty if the name is not known.
+func hydrateTags(d *plugin.QueryData, name string) map[string]string {
+	if name == "" {
+		return nil
+	}
+	for _, config := range d.Table.HydrateConfig {
+		if helpers.GetFunctionName(config.Func) == name {
+			return config.Tags
+		}
+	}
+	if list := d.Table.List; list != nil {
+		if list.ParentHydrate != nil && helpers.GetFunctionName(list.ParentHydrate) == name {
+			return list.ParentTags
+		}
+		if helpers.GetFunctionName(list.Hydrate) == name {
+			return list.Tags
+		}
+	}
+	if get := d.Table.Get; get != nil && helpers.GetFunctionName(get.Hydrate) == name {
+		return get.Tags
+	}
+	return nil
+}
+
+// apiErrorDetails are the error code, HTTP status code and request ID of an API error
+type apiErrorDetails struct {
+	Code       string
+	StatusCode int
+	RequestId  string
+}
+
+// getAPIErrorDetails returns the details of an OpenAPI, OSS or SLS error, or empty details for other errors
+func getAPIErrorDetails(err error) apiErrorDetails {
+	var sdkErr *tea.SDKError
+	if errors.As(err, &sdkErr) {
+		details := apiErrorDetails{Code: tea.StringValue(sdkErr.Code), StatusCode: tea.IntValue(sdkErr.StatusCode)}
+		// The data of the error is the response body
+		var data struct {
+			// Matches "requestId" as well, as field names are matched case insensitively
+			RequestId string `json:"RequestId"`
+		}
+		if json.Unmarshal([]byte(tea.StringValue(sdkErr.Data)), &data) == nil {
+			details.RequestId = data.RequestId
+		}
+		return details
+	}
+
+	var slsErr *sls.Error
+	if errors.As(err, &slsErr) {
+		return apiErrorDetails{Code: slsErr.Code, StatusCode: int(slsErr.HTTPCode), RequestId: slsErr.RequestID}
+	}
+
+	var ossErr *oss.ServiceError
+	if errors.As(err, &ossErr) {
+		return apiErrorDetails{Code: ossErr.Code, StatusCode: ossErr.StatusCode, RequestId: ossErr.RequestID}
+	}
+
+	return apiErrorDetails{}
+}
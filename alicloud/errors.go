@@ -2,10 +2,17 @@ package alicloud
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
-	"github.com/alibabacloud-go/tea/tea"
+	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
 )
 
 // isNotFoundError:: function which returns an ErrorPredicateWithContext for Alicloud API calls
@@ -15,68 +22,267 @@ func isNotFoundError(notFoundErrors []string) plugin.ErrorPredicateWithContext {
 			return false
 		}
 
-		alicloudConfig := GetConfig(d.Connection)
-
 		// If the get or list hydrate functions have an overriding IgnoreConfig
 		// defined using the isNotFoundError function, then it should
-		// also check for errors in the "ignore_error_codes" config argument
-		allErrors := append(notFoundErrors, alicloudConfig.IgnoreErrorCodes...)
+		// also check for errors ignored by the connection config
+		reason, ignored := ignoredErrorReason(ctx, d, notFoundErrors, err)
+		// Resources which are not found are the expected result of a get call, not a diagnostic
+		if ignored && reason != "not_found" {
+			recordIgnoredError(ctx, d, err, reason)
+		}
+		return ignored
+	}
+}
 
-		// Ignore dynamic endpoint resolution failures ("no such host") for services not available in all regions
-		if strings.Contains(err.Error(), "no such host") {
-			plugin.Logger(ctx).Debug("ignoring no such host error for unreachable region", "error", err)
-			return true
+// shouldIgnoreErrorPluginDefault:: Plugin level default function to ignore a set errors for hydrate functions based on the "ignore_error_codes" and "ignore_error_rules" config arguments
+func shouldIgnoreErrorPluginDefault() plugin.ErrorPredicateWithContext {
+	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
+		if err == nil {
+			return false
 		}
 
-		// V2 SDK error handling using SDK error codes
-		if sdkErr, ok := err.(*tea.SDKError); ok {
-			errCode := tea.StringValue(sdkErr.Code)
-			for _, pattern := range allErrors {
-				if strings.Contains(errCode, pattern) {
-					return true
-				}
-			}
+		reason, ignored := ignoredErrorReason(ctx, d, nil, err)
+		if ignored {
+			recordIgnoredError(ctx, d, err, reason)
+		}
+		return ignored
+	}
+}
+
+// ignoredErrorReason returns why an error is ignored: the not found codes of the hydrate call,
+// an unreachable region, or the "ignore_error_codes" or "ignore_error_rules" config arguments
+func ignoredErrorReason(ctx context.Context, d *plugin.QueryData, notFoundErrors []string, err error) (string, bool) {
+	// The not found codes of the plugin match anywhere in the error, e.g. "NotFound" matches "InvalidVpcId.NotFound"
+	for _, pattern := range notFoundErrors {
+		if strings.Contains(err.Error(), pattern) {
+			return "not_found", true
 		}
+	}
 
-		for _, pattern := range allErrors {
-			if strings.Contains(err.Error(), pattern) {
-				return true
-			}
+	// Ignore dynamic endpoint resolution failures ("no such host") for services not available in all regions
+	if strings.Contains(err.Error(), "no such host") {
+		return "unreachable_region", true
+	}
+
+	alicloudConfig := GetConfig(d.Connection)
+	details := getAPIErrorDetails(err)
+
+	// The codes of "ignore_error_codes" match anywhere in the error code or message, e.g. "Forbidden" matches "Forbidden.RAM"
+	for _, pattern := range alicloudConfig.IgnoreErrorCodes {
+		if strings.Contains(details.Code, pattern) || strings.Contains(err.Error(), pattern) {
+			return "ignore_error_codes", true
 		}
-		return false
 	}
+
+	rules, ruleErr := getIgnoreErrorRules(d.Connection)
+	if ruleErr != nil {
+		plugin.Logger(ctx).Error("ignoredErrorReason", "config_error", ruleErr)
+		return "", false
+	}
+
+	service, region := hydrateService(ctx, d), d.EqualsQualString(matrixKeyRegion)
+	for _, rule := range rules {
+		if rule.matches(d.Table.Name, service, region, details.Code, details.StatusCode) {
+			return "ignore_error_rules", true
+		}
+	}
+	return "", false
 }
 
-// shouldIgnoreErrorPluginDefault:: Plugin level default function to ignore a set errors for hydrate functions based on "ignore_error_codes" config argument
-func shouldIgnoreErrorPluginDefault() plugin.ErrorPredicateWithContext {
-	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
-		if err == nil {
-			return false
+// matchErrorCode matches an error code of "ignore_error_rules" exactly, or by prefix if the pattern ends with "*",
+// e.g. "Forbidden" matches "Forbidden" only and "Forbidden.*" matches "Forbidden.RAM"
+func matchErrorCode(pattern string, code string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(code, prefix)
+	}
+	return pattern == code
+}
+
+// hydrateService returns the service of the hydrate call whose error is checked, from the tags of its config,
+// or the service of the table if the call is not known
+func hydrateService(ctx context.Context, d *plugin.QueryData) string {
+	if service := hydrateTags(d, hydrateName(ctx))["service"]; service != "" {
+		return service
+	}
+	return tableService(d)
+}
+
+// hydrateNameKey is the context key of the name of the hydrate function whose error an ignore predicate checks
+type hydrateNameKey struct{}
+
+// hydrateName returns the name of the hydrate function whose error is checked, empty if it is not known
+func hydrateName(ctx context.Context) string {
+	name, _ := ctx.Value(hydrateNameKey{}).(string)
+	return name
+}
+
+// withHydrateName wraps an ignore predicate to pass it the name of the hydrate function it checks the errors of,
+// which the SDK does not
+func withHydrateName(hydrate plugin.HydrateFunc, predicate plugin.ErrorPredicateWithContext) *plugin.IgnoreConfig {
+	name := helpers.GetFunctionName(hydrate)
+	return &plugin.IgnoreConfig{
+		ShouldIgnoreErrorFunc: func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
+			return predicate(context.WithValue(ctx, hydrateNameKey{}, name), d, h, err)
+		},
+	}
+}
+
+// firstIgnorePredicate returns the first predicate set by the ignore configs, in the order the SDK defaults them
+func firstIgnorePredicate(configs ...*plugin.IgnoreConfig) plugin.ErrorPredicateWithContext {
+	for _, config := range configs {
+		if config != nil && config.ShouldIgnoreErrorFunc != nil {
+			return config.ShouldIgnoreErrorFunc
 		}
+	}
+	return nil
+}
 
-		alicloudConfig := GetConfig(d.Connection)
+// nameHydrateIgnoreConfigs sets the ignore config of every hydrate call of the tables of the plugin, defaulted
+// like the SDK does, to one which passes the predicate the name of the hydrate function. The column hydrate
+// functions without a config are given one.
+func nameHydrateIgnoreConfigs(p *plugin.Plugin) {
+	var defaultGet *plugin.IgnoreConfig
+	if p.DefaultGetConfig != nil {
+		defaultGet = p.DefaultGetConfig.IgnoreConfig
+	}
 
-		// Ignore dynamic endpoint resolution failures ("no such host") for services not available in all regions
-		if strings.Contains(err.Error(), "no such host") {
-			plugin.Logger(ctx).Debug("ignoring no such host error for unreachable region", "error", err)
-			return true
+	for _, table := range p.TableMap {
+		configured := map[string]bool{}
+		if table.Get != nil {
+			// The get config is used for the get function as a column hydrate function as well
+			configured[helpers.GetFunctionName(table.Get.Hydrate)] = true
+			if predicate := firstIgnorePredicate(table.Get.IgnoreConfig, defaultGet, table.DefaultIgnoreConfig, p.DefaultIgnoreConfig); predicate != nil {
+				table.Get.IgnoreConfig = withHydrateName(table.Get.Hydrate, predicate)
+			}
 		}
 
-		// V2 SDK error handling using SDK error codes
-		if sdkErr, ok := err.(*tea.SDKError); ok {
-			errCode := tea.StringValue(sdkErr.Code)
-			for _, pattern := range alicloudConfig.IgnoreErrorCodes {
-				if strings.Contains(errCode, pattern) {
-					return true
-				}
+		if list := table.List; list != nil {
+			// The ignore config of a list applies to its parent call if it has one, the errors of the child calls are not checked
+			hydrate := list.Hydrate
+			if list.ParentHydrate != nil {
+				hydrate = list.ParentHydrate
+			}
+			if predicate := firstIgnorePredicate(list.IgnoreConfig, table.DefaultIgnoreConfig, p.DefaultIgnoreConfig); predicate != nil {
+				list.IgnoreConfig = withHydrateName(hydrate, predicate)
 			}
 		}
 
-		for _, pattern := range alicloudConfig.IgnoreErrorCodes {
-			if strings.Contains(err.Error(), pattern) {
-				return true
+		for _, config := range table.HydrateConfig {
+			configured[helpers.GetFunctionName(config.Func)] = true
+		}
+		for _, column := range table.Columns {
+			if column.Hydrate != nil && !configured[helpers.GetFunctionName(column.Hydrate)] {
+				configured[helpers.GetFunctionName(column.Hydrate)] = true
+				table.HydrateConfig = append(table.HydrateConfig, plugin.HydrateConfig{Func: column.Hydrate})
+			}
+		}
+		for i := range table.HydrateConfig {
+			config := &table.HydrateConfig[i]
+			if predicate := firstIgnorePredicate(config.IgnoreConfig, table.DefaultIgnoreConfig, p.DefaultIgnoreConfig); predicate != nil {
+				config.IgnoreConfig = withHydrateName(config.Func, predicate)
 			}
 		}
+	}
+}
+
+// tableService returns the service of the list call of a table, or of its get call if it has no list call
+func tableService(d *plugin.QueryData) string {
+	if d.Table.List != nil && d.Table.List.Tags["service"] != "" {
+		return d.Table.List.Tags["service"]
+	}
+	if d.Table.Get != nil {
+		return d.Table.Get.Tags["service"]
+	}
+	return ""
+}
+
+// ignoreErrorRule is an entry of the "ignore_error_rules" config argument. An error is ignored
+// if it matches one of the error codes or HTTP status codes of a rule, the service of the call which
+// returned it and the table and region of the query match the rule. Empty service, tables or regions match any.
+type ignoreErrorRule struct {
+	Service     *string  `cty:"service"`
+	Tables      []string `cty:"tables"`
+	Regions     []string `cty:"regions"`
+	ErrorCodes  []string `cty:"error_codes"`
+	StatusCodes []int    `cty:"status_codes"`
+}
+
+func (r ignoreErrorRule) matches(table, service, region, code string, status int) bool {
+	if r.Service != nil && *r.Service != service {
+		return false
+	}
+	if len(r.Tables) > 0 && !slices.Contains(r.Tables, table) {
 		return false
 	}
+	if len(r.Regions) > 0 && !slices.Contains(r.Regions, region) {
+		return false
+	}
+
+	if code != "" && slices.ContainsFunc(r.ErrorCodes, func(pattern string) bool { return matchErrorCode(pattern, code) }) {
+		return true
+	}
+	return status != 0 && slices.Contains(r.StatusCodes, status)
+}
+
+// getIgnoreErrorRules parses the "ignore_error_rules" argument of the connection config
+func getIgnoreErrorRules(connection *plugin.Connection) ([]ignoreErrorRule, error) {
+	value := GetConfig(connection).IgnoreErrorRules
+	if value.IsNull() {
+		return nil, nil
+	}
+	if !value.CanIterateElements() || value.Type().IsMapType() || value.Type().IsObjectType() {
+		return nil, fmt.Errorf("connection config has invalid value for \"ignore_error_rules\": it must be a list of rules")
+	}
+
+	// Every attribute of a rule is optional, and lists are written as tuples, e.g. ["Forbidden"]
+	ruleType, err := gocty.ImpliedType(ignoreErrorRule{})
+	if err != nil {
+		return nil, err
+	}
+	ruleType = cty.ObjectWithOptionalAttrs(ruleType.AttributeTypes(), slices.Collect(maps.Keys(ruleType.AttributeTypes())))
+
+	var rules []ignoreErrorRule
+	services := map[string]bool{}
+	for i, element := range value.AsValueSlice() {
+		if element.Type().IsObjectType() {
+			for name := range element.Type().AttributeTypes() {
+				if !ruleType.HasAttribute(name) {
+					return nil, fmt.Errorf("connection config has invalid value for \"ignore_error_rules\" rule %d: unsupported attribute %q", i+1, name)
+				}
+			}
+		}
+
+		var rule ignoreErrorRule
+		element, err := convert.Convert(element, ruleType)
+		if err == nil {
+			err = gocty.FromCtyValue(element, &rule)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("connection config has invalid value for \"ignore_error_rules\" rule %d: %s", i+1, formatCtyError(err))
+		}
+		if len(rule.ErrorCodes) == 0 && len(rule.StatusCodes) == 0 {
+			return nil, fmt.Errorf("connection config has invalid value for \"ignore_error_rules\" rule %d: it must set \"error_codes\" or \"status_codes\"", i+1)
+		}
+		if rule.Service != nil {
+			services[*rule.Service] = true
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := validateServiceNames("ignore_error_rules", services); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// formatCtyError prefixes a conversion error with the attribute it occurred at, e.g. "tables: string required"
+func formatCtyError(err error) string {
+	var pathErr cty.PathError
+	if !errors.As(err, &pathErr) || len(pathErr.Path) == 0 {
+		return err.Error()
+	}
+	if step, ok := pathErr.Path[0].(cty.GetAttrStep); ok {
+		return step.Name + ": " + err.Error()
+	}
+	return err.Error()
 }
@@ -3,7 +3,6 @@ package alicloud
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"math"
 	"strings"
 	"time"
@@ -11,7 +10,6 @@ import (
 	cms "github.com/alibabacloud-go/cms-20190101/v10/client"
 	"github.com/alibabacloud-go/tea/tea"
 
-	"github.com/sethvargo/go-retry"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -96,18 +94,58 @@ type CMMetricRow struct {
 	Timestamp string
 }
 
-func getCMStartDateForGranularity(granularity string) string {
-	str := "2006-01-02T15:04:05Z"
+// cmMetricTimeFormat is the format of the StartTime and EndTime of the DescribeMetricList API, in UTC
+const cmMetricTimeFormat = "2006-01-02T15:04:05Z"
+
+// key columns of the cloud monitoring metric tables, range quals on timestamp are pushed down to the API
+func cmMetricKeyColumns() plugin.KeyColumnSlice {
+	return plugin.KeyColumnSlice{
+		{Name: "timestamp", Operators: []string{">", ">=", "=", "<", "<="}, Require: plugin.Optional},
+	}
+}
+
+// getCMWindowForGranularity returns how far back datapoints are listed if the query does not set a start time
+func getCMWindowForGranularity(granularity string) time.Duration {
 	switch strings.ToUpper(granularity) {
-	case "DAILY":
+	case "DAILY", "HOURLY":
 		// 30 days
-		return time.Now().AddDate(0, 0, -30).Format(str)
-	case "HOURLY":
-		// 30 days
-		return time.Now().AddDate(0, 0, -30).Format(str)
+		return 30 * 24 * time.Hour
 	}
 	// else 5 days
-	return time.Now().AddDate(0, 0, -5).Format(str)
+	return 5 * 24 * time.Hour
+}
+
+// getCMTimeRange returns the StartTime and EndTime of the metric request from the timestamp quals.
+// Without a lower bound the default window is listed, counted back from the upper bound or now.
+func getCMTimeRange(d *plugin.QueryData, window time.Duration) (time.Time, time.Time) {
+	var startTime, endTime time.Time
+	if d.Quals["timestamp"] != nil {
+		for _, q := range d.Quals["timestamp"].Quals {
+			value := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case ">", ">=":
+				if value.After(startTime) {
+					startTime = value
+				}
+			case "<", "<=":
+				if endTime.IsZero() || value.Before(endTime) {
+					endTime = value
+				}
+			case "=":
+				startTime, endTime = value, value
+			}
+		}
+	}
+
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+	if startTime.IsZero() {
+		startTime = endTime.Add(-window)
+	}
+
+	// The API excludes datapoints at StartTime, widen the range so bounds such as ">=" and "=" keep them
+	return startTime.Add(-time.Second), endTime
 }
 
 func getCMPeriodForGranularity(granularity string) string {
@@ -123,9 +161,11 @@ func getCMPeriodForGranularity(granularity string) string {
 	return "300"
 }
 
-func getCustomError(errorMessage string) error {
+// getCustomError returns a failure reported in a successful response as an SDK error
+func getCustomError(code string, message string) error {
 	return tea.NewSDKError(map[string]interface{}{
-		"message":    errorMessage,
+		"code":       code,
+		"message":    message,
 		"statusCode": 500,
 	})
 }
@@ -138,76 +178,77 @@ func listCMMetricStatistics(ctx context.Context, d *plugin.QueryData, granularit
 		return nil, err
 	}
 
+	startTime, endTime := getCMTimeRange(d, getCMWindowForGranularity(granularity))
+	if !startTime.Before(endTime) {
+		return nil, nil
+	}
+
 	request := &cms.DescribeMetricListRequest{
 		Namespace:  &namespace,
 		MetricName: &metricName,
 		Dimensions: tea.String("[{\"" + dimensionName + "\": \"" + dimensionValue + "\"}]"),
-		StartTime:  tea.String(getCMStartDateForGranularity(granularity)),
-		EndTime:    tea.String(time.Now().Format("2006-01-02T15:04:05Z")),
+		StartTime:  tea.String(startTime.UTC().Format(cmMetricTimeFormat)),
+		EndTime:    tea.String(endTime.UTC().Format(cmMetricTimeFormat)),
 		Period:     tea.String(getCMPeriodForGranularity(granularity)),
 	}
 
-	var stats *cms.DescribeMetricListResponse
-
-	b := retry.NewFibonacci(100 * time.Millisecond)
+	for {
+		stats, err := describeCMMetricList(ctx, client, request)
+		if err != nil {
+			return nil, err
+		}
 
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		stats, err = client.DescribeMetricList(request)
-		if err != nil || stats.Body.Datapoints == nil || *stats.Body.Datapoints == "" {
-			// Common server error retry
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if serverErr.Code != nil && *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				return err
-			}
-			/**
-			* At some point of the time we are getting the error as success response(%!v(PANIC=String method: runtime error: invalid memory address or nil pointer dereference)") which is not expected.
-			* If we will retry the api call then we will able to get the data.
-			**/
-			if *stats.Body.Datapoints == "" && !*stats.Body.Success {
-				err = getCustomError(fmt.Sprint(stats))
-				return retry.RetryableError(err)
-			}
+		// As some point of the time we are getting the error in response not in the error part.
+		// Response in stats variable: "%!v(PANIC=String method: runtime error: invalid memory address or nil pointer dereference)"
+		if stats.Body.Datapoints == nil || *stats.Body.Datapoints == "" {
+			return nil, nil
+		}
 
+		var results []map[string]interface{}
+		err = json.Unmarshal([]byte(*stats.Body.Datapoints), &results)
+		if err != nil {
+			return nil, err
 		}
-		return nil
-	})
-	if err != nil {
-		return nil, err
-	}
+		for _, pointValue := range results {
+			d.StreamListItem(ctx, &CMMetricRow{
+				DimensionName:  dimensionName,
+				DimensionValue: pointValue[dimensionName].(string),
+				Namespace:      namespace,
+				MetricName:     metricName,
+				Average:        pointValue["Average"].(float64),
+				Maximum:        pointValue["Maximum"].(float64),
+				Minimum:        pointValue["Minimum"].(float64),
+				Timestamp:      formatTime(pointValue["timestamp"].(float64)),
+			})
 
-	var results []map[string]interface{}
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
 
-	// As some point of the time we are getting the error in response not in the error part.
-	// Response in stats variable: "%!v(PANIC=String method: runtime error: invalid memory address or nil pointer dereference)"
-	if stats.Body.Datapoints == nil || *stats.Body.Datapoints == "" {
-		return nil, nil
+		if stats.Body.NextToken == nil || *stats.Body.NextToken == "" {
+			return nil, nil
+		}
+		request.NextToken = stats.Body.NextToken
 	}
+}
 
-	err = json.Unmarshal([]byte(*stats.Body.Datapoints), &results)
+// describeCMMetricList returns a page of datapoints. Throttled calls are retried by the client, and the
+// failures DescribeMetricList reports in a successful response are returned as errors.
+func describeCMMetricList(ctx context.Context, client *cms.Client, request *cms.DescribeMetricListRequest) (*cms.DescribeMetricListResponse, error) {
+	stats, err := client.DescribeMetricList(request)
 	if err != nil {
 		return nil, err
 	}
-	for _, pointValue := range results {
-		d.StreamListItem(ctx, &CMMetricRow{
-			DimensionName:  dimensionName,
-			DimensionValue: pointValue[dimensionName].(string),
-			Namespace:      namespace,
-			MetricName:     metricName,
-			Average:        pointValue["Average"].(float64),
-			Maximum:        pointValue["Maximum"].(float64),
-			Minimum:        pointValue["Minimum"].(float64),
-			Timestamp:      formatTime(pointValue["timestamp"].(float64)),
-		})
+	if stats == nil || stats.Body == nil {
+		return nil, getCustomError("", "DescribeMetricList returned an empty response")
 	}
-
-	if stats.Body.NextToken != nil && *stats.Body.NextToken != "" {
-		request.NextToken = stats.Body.NextToken
+	if stats.Body.Success != nil && !*stats.Body.Success {
+		return nil, getCustomError(tea.StringValue(stats.Body.Code), tea.StringValue(stats.Body.Message))
 	}
 
-	return nil, nil
+	return stats, nil
 }
 
 func formatTime(timestamp float64) string {
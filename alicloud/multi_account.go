@@ -0,0 +1,330 @@
+package alicloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	openapiutil "github.com/alibabacloud-go/darabonba-openapi/v2/utils"
+	"github.com/alibabacloud-go/tea/dara"
+
+	sts "github.com/alibabacloud-go/sts-20150401/v2/client"
+
+	credential "github.com/aliyun/credentials-go/credentials"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+)
+
+const (
+	matrixKeyAccount = "account_id"
+
+	// memberAccountsAll in "member_accounts" enumerates the members of the resource directory
+	memberAccountsAll = "*"
+
+	// Role created by Resource Directory in every member account it creates, trusted by the management account
+	defaultMemberRoleName = "ResourceDirectoryAccountAccessRole"
+
+	// Maximum page size of the Resource Manager ListAccounts API
+	listAccountsPageSize = 100
+)
+
+// activeMemberAccountStatuses are the statuses of resource directory members which can be queried
+var activeMemberAccountStatuses = []string{"CreateSuccess", "InviteSuccess", "PromoteSuccess"}
+
+// resourceDirectoryAccount is a member account returned by the Resource Manager ListAccounts API
+type resourceDirectoryAccount struct {
+	AccountId   string `json:"AccountId"`
+	DisplayName string `json:"DisplayName"`
+	Status      string `json:"Status"`
+}
+
+type listResourceDirectoryAccountsResponse struct {
+	Accounts struct {
+		Account []resourceDirectoryAccount `json:"Account"`
+	} `json:"Accounts"`
+	TotalCount int `json:"TotalCount"`
+}
+
+// isMultiAccountConnection returns true if the connection queries the accounts set in "member_accounts"
+// instead of the account of its credentials
+func isMultiAccountConnection(connection *plugin.Connection) bool {
+	return len(GetConfig(connection).MemberAccounts) > 0
+}
+
+// BuildAccountList :: return a list of matrix items, one per account queried by the connection
+func BuildAccountList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	accountIds, err := getMatrixAccountIds(ctx, d)
+	if err != nil {
+		// Without a matrix the table is queried once, and the service clients return the error
+		plugin.Logger(ctx).Error("BuildAccountList", "error", err)
+		return nil
+	}
+	// A single account connection is queried once with its own credentials
+	if accountIds == nil {
+		return nil
+	}
+
+	matrix := make([]map[string]interface{}, len(accountIds))
+	for i, accountId := range accountIds {
+		matrix[i] = map[string]interface{}{matrixKeyAccount: accountId}
+	}
+	return matrix
+}
+
+// getMatrixAccountIds returns the member accounts queried by the connection if "member_accounts" is set.
+// Otherwise the connection queries the account of its credentials. This account is only looked up if the query
+// filters on "account_id", so that the SDK skips the connection when it does not match; without such a qual
+// getMatrixAccountIds returns nil and building the matrix makes no STS call.
+func getMatrixAccountIds(ctx context.Context, d *plugin.QueryData) ([]string, error) {
+	if !isMultiAccountConnection(d.Connection) {
+		if !hasAccountIdEqualsQual(d) {
+			return nil, nil
+		}
+		accountId, err := getConnectionAccountId(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		return []string{accountId}, nil
+	}
+
+	accountIds, err := getMemberAccountsCached(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+	return accountIds.([]string), nil
+}
+
+// hasAccountIdEqualsQual returns true if the query has an "=" qual on "account_id", which the SDK matches
+// against the "account_id" of the matrix items
+func hasAccountIdEqualsQual(d *plugin.QueryData) bool {
+	if d.QueryContext == nil || d.QueryContext.UnsafeQuals[matrixKeyAccount] == nil {
+		return false
+	}
+	for _, qual := range d.QueryContext.UnsafeQuals[matrixKeyAccount].Quals {
+		if qual.GetStringValue() == quals.QualOperatorEqual {
+			return true
+		}
+	}
+	return false
+}
+
+var getMemberAccountsCached = plugin.HydrateFunc(getMemberAccountsUncached).Memoize()
+
+// getMemberAccountsUncached returns the accounts listed in "member_accounts", or the account of the connection
+// and the active members of its resource directory if "member_accounts" is ["*"]
+func getMemberAccountsUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	alicloudConfig := GetConfig(d.Connection)
+
+	if slices.Contains(alicloudConfig.MemberAccounts, memberAccountsAll) {
+		if len(alicloudConfig.MemberAccounts) > 1 {
+			return nil, fmt.Errorf("connection config has invalid value for \"member_accounts\": %q can not be combined with account IDs", memberAccountsAll)
+		}
+		return listMemberAccountIds(ctx, d)
+	}
+
+	var accountIds, invalid []string
+	for _, accountId := range alicloudConfig.MemberAccounts {
+		if _, err := strconv.ParseUint(accountId, 10, 64); err != nil {
+			invalid = append(invalid, accountId)
+			continue
+		}
+		if !slices.Contains(accountIds, accountId) {
+			accountIds = append(accountIds, accountId)
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("connection config has invalid account IDs in \"member_accounts\": %s", strings.Join(invalid, ", "))
+	}
+
+	return accountIds, nil
+}
+
+// listMemberAccountIds returns the account of the connection followed by the active members of its resource directory
+func listMemberAccountIds(ctx context.Context, d *plugin.QueryData) ([]string, error) {
+	connectionAccountId, err := getConnectionAccountId(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := listResourceDirectoryAccounts(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource directory member accounts: %v", err)
+	}
+
+	accountIds := []string{connectionAccountId}
+	for _, member := range members {
+		if !slices.Contains(activeMemberAccountStatuses, member.Status) {
+			plugin.Logger(ctx).Debug("listMemberAccountIds", "skipped_account_id", member.AccountId, "status", member.Status)
+			continue
+		}
+		if !slices.Contains(accountIds, member.AccountId) {
+			accountIds = append(accountIds, member.AccountId)
+		}
+	}
+
+	plugin.Logger(ctx).Info("listMemberAccountIds", "accounts", len(accountIds))
+	return accountIds, nil
+}
+
+// listResourceDirectoryAccounts returns all members of the resource directory the connection credentials manage
+func listResourceDirectoryAccounts(ctx context.Context, d *plugin.QueryData) ([]resourceDirectoryAccount, error) {
+	client, err := ResourceManagerService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Follow the scheme of an "endpoints" override, like the clients built with newOpenAPIConfig
+	endpoint, err := getServiceEndpoint(d.Connection, "resourcemanager", GetDefaultRegion(d.Connection))
+	if err != nil {
+		return nil, err
+	}
+	protocol := "HTTPS"
+	if endpoint.Protocol != "" {
+		protocol = strings.ToUpper(endpoint.Protocol)
+	}
+
+	params := &openapiutil.Params{
+		Action:      dara.String("ListAccounts"),
+		Version:     dara.String("2020-03-31"),
+		Protocol:    dara.String(protocol),
+		Pathname:    dara.String("/"),
+		Method:      dara.String("POST"),
+		AuthType:    dara.String("AK"),
+		Style:       dara.String("RPC"),
+		ReqBodyType: dara.String("formData"),
+		BodyType:    dara.String("json"),
+	}
+
+	var accounts []resourceDirectoryAccount
+	for pageNumber := 1; ; pageNumber++ {
+		request := &openapiutil.OpenApiRequest{
+			Query: map[string]*string{
+				"PageNumber": dara.String(strconv.Itoa(pageNumber)),
+				"PageSize":   dara.String(strconv.Itoa(listAccountsPageSize)),
+			},
+		}
+
+		response, err := client.CallApiWithCtx(ctx, params, request, &dara.RuntimeOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		var page listResourceDirectoryAccountsResponse
+		if err := dara.Convert(response["body"], &page); err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, page.Accounts.Account...)
+		if len(page.Accounts.Account) < listAccountsPageSize || len(accounts) >= page.TotalCount {
+			return accounts, nil
+		}
+	}
+}
+
+// getConnectionAccountId returns the account of the connection credentials, before any member account role is assumed
+func getConnectionAccountId(ctx context.Context, d *plugin.QueryData) (string, error) {
+	accountId, err := getConnectionAccountIdMemoize(ctx, d, nil)
+	if err != nil {
+		return "", err
+	}
+	return accountId.(string), nil
+}
+
+var getConnectionAccountIdMemoize = plugin.HydrateFunc(getConnectionAccountIdUncached).Memoize()
+
+func getConnectionAccountIdUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+	cfg := credCfg.(*CredentialConfig)
+
+	clientCfg, err := newOpenAPIConfig(d, "sts", cfg.Cred, cfg.DefaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := sts.NewClient(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	callerIdentity, err := client.GetCallerIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return dara.StringValue(callerIdentity.Body.AccountId), nil
+}
+
+// getAccountCredentialConfig returns the credentials for the account of the matrix item.
+// Member accounts are accessed by assuming "member_role_name" in the account with the connection credentials,
+// the account of the connection itself uses the connection credentials directly.
+func getAccountCredentialConfig(ctx context.Context, d *plugin.QueryData) (*CredentialConfig, error) {
+	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+	cfg := credCfg.(*CredentialConfig)
+
+	if !isMultiAccountConnection(d.Connection) {
+		return cfg, nil
+	}
+
+	accountId := d.EqualsQualString(matrixKeyAccount)
+	if accountId == "" {
+		// The account matrix could not be built, return the reason
+		if _, err := getMemberAccountsCached(ctx, d, nil); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("account could not be determined, tables must be queried per account when \"member_accounts\" is set")
+	}
+
+	connectionAccountId, err := getConnectionAccountId(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	if accountId == connectionAccountId {
+		return cfg, nil
+	}
+
+	cacheKey := fmt.Sprintf("credential-%s", accountId)
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*CredentialConfig), nil
+	}
+
+	alicloudConfig := GetConfig(d.Connection)
+	roleName := defaultMemberRoleName
+	if alicloudConfig.MemberRoleName != nil {
+		roleName = *alicloudConfig.MemberRoleName
+	}
+	roleArn := fmt.Sprintf("acs:ram::%s:role/%s", accountId, roleName)
+
+	roleProvider, err := newAssumeRoleCredentialsProvider(d, cfg.Provider, assumeRoleRequest{RoleArn: roleArn})
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &synchronizedCredentialsProvider{provider: roleProvider}
+	memberCfg := &CredentialConfig{
+		Cred:          credential.FromCredentialsProvider(provider.GetProviderName(), provider),
+		Provider:      provider,
+		DefaultRegion: cfg.DefaultRegion,
+		Source:        cfg.Source,
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, memberCfg)
+	return memberCfg, nil
+}
+
+// accountCacheKey scopes a connection cache key to the account of the matrix item, if any
+func accountCacheKey(d *plugin.QueryData, key string) string {
+	if accountId := d.EqualsQualString(matrixKeyAccount); accountId != "" {
+		return key + "-" + accountId
+	}
+	return key
+}
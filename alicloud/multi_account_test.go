@@ -0,0 +1,28 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSingleAccountMatrixMakesNoSTSCall(t *testing.T) {
+	stub := newOpenAPIStub(t, &stubInteraction{
+		Service: "ram",
+		Action:  "ListUsers",
+		Body:    json.RawMessage(`{"IsTruncated": false, "Users": {"User": [{"UserName": "alice"}]}}`),
+	})
+	connection := newTestConnection(t, stub, "")
+
+	rows, err := connection.query("alicloud_ram_user", []string{"name"}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("expected 1 row, got %d", len(rows))
+	}
+	// The account is only looked up for the account_id column, which is not queried
+	if calls := stub.calls("sts", "GetCallerIdentity"); calls != 0 {
+		t.Errorf("expected no GetCallerIdentity call, got %d", calls)
+	}
+	stub.verify(t)
+}
@@ -2,27 +2,87 @@ package alicloud
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path"
 	"slices"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+
+	ecs "github.com/alibabacloud-go/ecs-20140526/v7/client"
+	sae "github.com/alibabacloud-go/sae-20190506/v2/client"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
 const matrixKeyRegion = "region"
 
-// BuildRegionList :: return a list of matrix items, one per region specified in the connection config
-func BuildRegionList(_ context.Context, d *plugin.QueryData) []map[string]interface{} {
-	// retrieve regions from connection config
-	alicloudConfig := GetConfig(d.Connection)
+// Timeout of the DNS lookup used to check if a service endpoint exists in a region
+const regionEndpointLookupTimeout = 3 * time.Second
 
-	if alicloudConfig.Regions != nil {
-		regions := GetConfig(d.Connection).Regions
+// serviceRegionResolvers return the regions a service is available in, for services which are not available in every region
+var serviceRegionResolvers = map[string]plugin.HydrateFunc{
+	"fc":  plugin.HydrateFunc(listFunctionComputeRegions).Memoize(),
+	"sae": plugin.HydrateFunc(listSAERegions).Memoize(),
+}
 
-		if len(getInvalidRegions(regions)) > 0 {
-			panic("\n\nConnection config have invalid regions: " + strings.Join(getInvalidRegions(regions), ",") + ". Edit your connection configuration file and then restart Steampipe.")
-		}
+// BuildRegionList :: return a list of matrix items, one per account and region queried by the connection
+func BuildRegionList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	regions, err := getConnectionRegions(ctx, d)
+	if err != nil {
+		// Without a matrix the table is queried once, and the service clients return the error
+		plugin.Logger(ctx).Error("BuildRegionList", "error", err)
+		return nil
+	}
+
+	return buildAccountRegionMatrix(ctx, d, regions)
+}
+
+// BuildFunctionComputeRegionList :: return the matrix items of BuildRegionList in regions where Function Compute is available
+func BuildFunctionComputeRegionList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	return buildServiceRegionList(ctx, d, "fc")
+}
 
-		// validate regions list
+// BuildSAERegionList :: return the matrix items of BuildRegionList in regions where Serverless App Engine is available
+func BuildSAERegionList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	return buildServiceRegionList(ctx, d, "sae")
+}
+
+func buildServiceRegionList(ctx context.Context, d *plugin.QueryData, service string) []map[string]interface{} {
+	regions, err := getConnectionRegions(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("buildServiceRegionList", "service", service, "error", err)
+		return nil
+	}
+
+	serviceRegions, err := serviceRegionResolvers[service](ctx, d, nil)
+	if err != nil {
+		// Query every region rather than silently skipping some, regions without the service return an API error
+		plugin.Logger(ctx).Warn("buildServiceRegionList", "service", service, "error", err)
+		return buildAccountRegionMatrix(ctx, d, regions)
+	}
+
+	available := serviceRegions.([]string)
+	regions = slices.DeleteFunc(slices.Clone(regions), func(region string) bool {
+		return !slices.Contains(available, region)
+	})
+
+	return buildAccountRegionMatrix(ctx, d, regions)
+}
+
+// buildAccountRegionMatrix returns one matrix item per account and region.
+// The matrix is keyed by region only for a single account connection, or if the accounts can not be determined,
+// in which case the service clients return the error.
+func buildAccountRegionMatrix(ctx context.Context, d *plugin.QueryData, regions []string) []map[string]interface{} {
+	accountIds, err := getMatrixAccountIds(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("buildAccountRegionMatrix", "error", err)
+	}
+	if err != nil || accountIds == nil {
 		matrix := make([]map[string]interface{}, len(regions))
 		for i, region := range regions {
 			matrix[i] = map[string]interface{}{matrixKeyRegion: region}
@@ -30,91 +90,234 @@ func BuildRegionList(_ context.Context, d *plugin.QueryData) []map[string]interf
 		return matrix
 	}
 
-	return []map[string]interface{}{
-		{matrixKeyRegion: GetDefaultRegion(d.Connection)},
+	matrix := make([]map[string]interface{}, 0, len(accountIds)*len(regions))
+	for _, accountId := range accountIds {
+		for _, region := range regions {
+			matrix = append(matrix, map[string]interface{}{
+				matrixKeyAccount: accountId,
+				matrixKeyRegion:  region,
+			})
+		}
 	}
+	return matrix
 }
 
-func BuildFunctionComputeRegionList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
-	fcRegions := []string{
-		"cn-beijing",
-		"cn-chengdu",
-		"cn-hangzhou",
-		"cn-hongkong",
-		"cn-huhehaote",
-		"cn-qingdao",
-		"cn-shanghai",
-		"cn-shanghai-finance-1",
-		"cn-shenzhen",
-		"cn-wulanchabu",
-		"cn-zhangjiakou",
-		"ap-northeast-1",
-		"ap-northeast-2",
-		"ap-southeast-1",
-		"ap-southeast-3",
-		"ap-southeast-5",
-		"ap-southeast-7",
-		"eu-central-1",
-		"eu-west-1",
-		"me-central-1",
-		"us-east-1",
-		"us-west-1",
-	}
-	regions := BuildRegionList(ctx, d)
-	intersect := make([]map[string]interface{}, 0, len(regions))
-
-	for _, mp := range regions {
-		region, ok := mp["region"].(string)
-		if !ok || !slices.Contains(fcRegions, region) {
-			continue
+// getConnectionRegions returns the regions queried by the connection
+func getConnectionRegions(ctx context.Context, d *plugin.QueryData) ([]string, error) {
+	regions, err := getConnectionRegionsMemoize(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+	return regions.([]string), nil
+}
+
+// regionResolutionError returns the error of resolving the "regions" argument, if any.
+// The region matrix is empty in that case, so service clients see no region and return this error instead.
+func regionResolutionError(ctx context.Context, d *plugin.QueryData) error {
+	_, err := getConnectionRegions(ctx, d)
+	return err
+}
+
+var getConnectionRegionsMemoize = plugin.HydrateFunc(getConnectionRegionsUncached).Memoize()
+
+// getConnectionRegionsUncached resolves the "regions" argument against the regions returned by ECS DescribeRegions.
+// Entries may be region names or glob patterns, e.g. "*" or "cn-*".
+func getConnectionRegionsUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	alicloudConfig := GetConfig(d.Connection)
+
+	if alicloudConfig.Regions == nil {
+		return []string{GetDefaultRegion(d.Connection)}, nil
+	}
+
+	for _, entry := range alicloudConfig.Regions {
+		if _, err := path.Match(entry, ""); err != nil {
+			return nil, fmt.Errorf("connection config has invalid pattern in \"regions\": %s", entry)
+		}
+	}
+
+	available, err := listAvailableRegions(ctx, d)
+	if err != nil {
+		if slices.ContainsFunc(alicloudConfig.Regions, isRegionPattern) {
+			return nil, fmt.Errorf("failed to list regions to resolve the patterns in \"regions\": %v", err)
+		}
+
+		// Region names are used as they are, an unknown region fails on its first API call
+		plugin.Logger(ctx).Warn("getConnectionRegionsUncached", "unvalidated_regions", alicloudConfig.Regions, "error", err)
+		var regions []string
+		for _, region := range alicloudConfig.Regions {
+			if !slices.Contains(regions, region) {
+				regions = append(regions, region)
+			}
 		}
-		intersect = append(intersect, mp)
+		return regions, nil
 	}
 
-	return intersect
+	return resolveRegions(alicloudConfig.Regions, available)
 }
 
-func BuildSAERegionList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
-	saeRegions := []string{
-		"cn-beijing",
-		"cn-hongkong",
-		"cn-shanghai",
-		"ap-northeast-1",
-		"ap-southeast-1",
-		"ap-southeast-5",
-		"eu-central-1",
-	}
-	regions := BuildRegionList(ctx, d)
-	intersect := make([]map[string]interface{}, 0, len(regions))
-
-	for _, mp := range regions {
-		region, ok := mp["region"].(string)
-		if !ok || !slices.Contains(saeRegions, region) {
-			continue
+// resolveRegions expands the entries of the "regions" argument against the available regions, in the order
+// of the argument. Entries which match no available region are returned in an error.
+func resolveRegions(entries []string, available []string) ([]string, error) {
+	var regions, unknown []string
+	for _, entry := range entries {
+		matched := false
+		for _, region := range available {
+			if ok, _ := path.Match(entry, region); !ok {
+				continue
+			}
+			matched = true
+			if !slices.Contains(regions, region) {
+				regions = append(regions, region)
+			}
+		}
+		if !matched {
+			unknown = append(unknown, entry)
 		}
-		intersect = append(intersect, mp)
 	}
 
-	return intersect
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("connection config has unknown regions: %s. Available regions are: %s. Edit your connection configuration file and then restart Steampipe", strings.Join(unknown, ", "), strings.Join(available, ", "))
+	}
+
+	return regions, nil
 }
 
-func getValidRegions() []string {
-	return []string{
-		"cn-beijing", "cn-beijing-finance-1", "cn-chengdu", "cn-guangzhou", "cn-hangzhou", "cn-heyuan", "cn-hongkong",
-		"cn-huhehaote", "cn-qingdao", "cn-shanghai", "cn-shanghai-finance-1", "cn-shenzhen", "cn-shenzhen-finance-1",
-		"cn-wulanchabu", "cn-zhangjiakou", "ap-northeast-1", "ap-northeast-2", "ap-south-1", "ap-southeast-1",
-		"ap-southeast-2", "ap-southeast-3", "ap-southeast-5", "ap-southeast-6", "ap-southeast-7", "eu-central-1",
-		"eu-west-1", "me-east-1", "me-central-1", "us-east-1", "us-west-1", "cn-wuhan-lr", "cn-nanjing", "cn-fuzhou",
+// isRegionPattern returns true if an entry of the "regions" argument is a glob pattern
+func isRegionPattern(entry string) bool {
+	return strings.ContainsAny(entry, "*?[")
+}
+
+var listAvailableRegionsMemoize = plugin.HydrateFunc(listAvailableRegionsUncached).Memoize()
+
+// listAvailableRegions returns the sorted IDs of the regions available to the connection
+func listAvailableRegions(ctx context.Context, d *plugin.QueryData) ([]string, error) {
+	regions, err := listAvailableRegionsMemoize(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+	return regions.([]string), nil
+}
+
+func listAvailableRegionsUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+	cfg := credCfg.(*CredentialConfig)
+
+	clientCfg, err := newOpenAPIConfig(d, "ecs", cfg.Cred, cfg.DefaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ecs.NewClient(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.DescribeRegions(&ecs.DescribeRegionsRequest{
+		AcceptLanguage: dara.String("en-US"),
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	var regions []string
+	for _, region := range response.Body.Regions.Region {
+		regions = append(regions, dara.StringValue(region.RegionId))
+	}
+	slices.Sort(regions)
+
+	return regions, nil
 }
 
-func getInvalidRegions(regions []string) []string {
-	alicloudRegions := getValidRegions()
-	invalidRegions := []string{}
-	for _, region := range regions {
-		if !slices.Contains(alicloudRegions, region) {
-			invalidRegions = append(invalidRegions, region)
+// listSAERegions returns the regions where Serverless App Engine is available, from the SAE DescribeRegions API
+func listSAERegions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+	cfg := credCfg.(*CredentialConfig)
+
+	clientCfg, err := newOpenAPIConfig(d, "sae", cfg.Cred, cfg.DefaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := sae.NewClient(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.DescribeRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	var regions []string
+	if response.Body.Regions != nil {
+		for _, region := range response.Body.Regions.Region {
+			regions = append(regions, dara.StringValue(region.RegionId))
 		}
 	}
-	return invalidRegions
+
+	return regions, nil
+}
+
+// listFunctionComputeRegions returns the regions where Function Compute is available.
+// Function Compute has no API to list its regions, so a region is available if its endpoint exists in DNS.
+func listFunctionComputeRegions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	regions, err := getConnectionRegions(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// An endpoint override, e.g. a proxy, tells nothing about the availability of the service
+	if _, ok := GetConfig(d.Connection).Endpoints["fc"]; ok {
+		return regions, nil
+	}
+
+	var wg sync.WaitGroup
+	available := make([]bool, len(regions))
+	for i, region := range regions {
+		endpoint, err := getServiceEndpoint(d.Connection, "fc", region)
+		if err != nil {
+			return nil, err
+		}
+
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			available[i] = endpointExists(ctx, host)
+		}(i, endpoint.Host)
+	}
+	wg.Wait()
+
+	var fcRegions []string
+	for i, region := range regions {
+		if available[i] {
+			fcRegions = append(fcRegions, region)
+		}
+	}
+
+	return fcRegions, nil
+}
+
+// endpointExists returns false only if DNS reports that the host does not exist.
+// Other lookup failures are not conclusive, so the host is assumed to exist.
+func endpointExists(ctx context.Context, host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, regionEndpointLookupTimeout)
+	defer cancel()
+
+	_, err := net.DefaultResolver.LookupHost(ctx, host)
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	return true
 }
@@ -0,0 +1,66 @@
+package alicloud
+
+import (
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Port range of a security group rule or network ACL entry which applies to all ports
+	allPortsRange = "-1/-1"
+
+	minPort = 1
+	maxPort = 65535
+)
+
+// Protocols of security group rules and network ACL entries whose port ranges are ports
+var portProtocols = []string{"tcp", "udp", "all"}
+
+// parsePortRange parses a port range such as "22/22" or "-1/-1", returning false for protocols
+// without ports, e.g. ICMP, and for rules which use a port list instead of a port range
+func parsePortRange(protocol string, portRange string) (int64, int64, bool) {
+	if !slices.Contains(portProtocols, strings.ToLower(protocol)) {
+		return 0, 0, false
+	}
+	if portRange == allPortsRange {
+		return minPort, maxPort, true
+	}
+
+	from, to, found := strings.Cut(portRange, "/")
+	if !found {
+		return 0, 0, false
+	}
+	fromPort, err := strconv.ParseInt(from, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	toPort, err := strconv.ParseInt(to, 10, 64)
+	if err != nil || toPort < fromPort {
+		return 0, 0, false
+	}
+	return fromPort, toPort, true
+}
+
+// isAnyAddressCidr returns true for CIDR blocks which match every address, e.g. 0.0.0.0/0 or ::/0
+func isAnyAddressCidr(cidr string) bool {
+	prefix, err := netip.ParsePrefix(cidr)
+	return err == nil && prefix.Bits() == 0
+}
+
+// parseCidrOrAddress parses a CIDR block, or a single address as a /32 or /128 block
+func parseCidrOrAddress(value string) (netip.Prefix, error) {
+	if !strings.Contains(value, "/") {
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
@@ -0,0 +1,454 @@
+package alicloud
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Credentials and account of the connections created by the test harness
+const (
+	stubAccessKeyId     = "LTAIstubaccesskey"
+	stubAccessKeySecret = "stub-access-key-secret"
+	stubAccountId       = "1234567890123456"
+)
+
+// stubInteraction is a recorded API call: the request it matches and the response the stub replays.
+// Empty fields of the request part match any value.
+type stubInteraction struct {
+	// Service is a key of serviceEndpointTemplates, e.g. "ram" or "oss"
+	Service string `json:"service"`
+	// Action is the OpenAPI action, sent in the x-acs-action header by RPC and ROA clients
+	Action string `json:"action,omitempty"`
+	Method string `json:"method,omitempty"`
+	// Path of the request, a trailing "*" matches any suffix. OSS paths start after the bucket name.
+	Path string `json:"path,omitempty"`
+	// Bucket is the OSS bucket of the request, Project the SLS project
+	Bucket  string `json:"bucket,omitempty"`
+	Project string `json:"project,omitempty"`
+	// Params must all be present in the query string or form body of the request
+	Params map[string]string `json:"params,omitempty"`
+
+	// Status defaults to 200, or 400 if ErrorCode is set
+	Status  int               `json:"status,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is sent as is if it is a JSON string (e.g. an OSS XML document), otherwise as JSON
+	Body json.RawMessage `json:"body,omitempty"`
+	// ErrorCode and ErrorMessage build the error document of the service instead of Body
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	// Disconnect closes the connection without a response, for a connection error in the client
+	Disconnect bool `json:"disconnect,omitempty"`
+
+	// Times is the number of requests the interaction answers, 0 for any number.
+	// Once it is used up, the next matching interaction answers, e.g. to throttle a single call.
+	Times int `json:"times,omitempty"`
+	// Optional interactions are not required to be used by the query
+	Optional bool `json:"optional,omitempty"`
+
+	calls int
+}
+
+// stubRequest is a request received by the stub, as it is matched against the interactions
+type stubRequest struct {
+	Service string
+	Action  string
+	Method  string
+	Path    string
+	Bucket  string
+	Project string
+	Params  url.Values
+}
+
+func (r stubRequest) String() string {
+	var target []string
+	for _, part := range []string{r.Action, r.Bucket, r.Project} {
+		if part != "" {
+			target = append(target, part)
+		}
+	}
+	return fmt.Sprintf("%s %s %s%s?%s", r.Service, strings.Join(target, " "), r.Method, r.Path, r.Params.Encode())
+}
+
+// openAPIStub is a local stand-in for the Alibaba Cloud APIs. It runs one server per service
+// of serviceEndpointTemplates, so every service client can be pointed at it through the "endpoints"
+// connection config argument, checks the request signatures and replays the recorded interactions.
+type openAPIStub struct {
+	mu           sync.Mutex
+	servers      map[string]*httptest.Server
+	interactions []*stubInteraction
+	unmatched    []string
+}
+
+// newOpenAPIStub starts the servers of the stub, which are closed at the end of the test.
+// The identity of the connection is answered by default, tests can add their own interaction to override it.
+func newOpenAPIStub(t *testing.T, interactions ...*stubInteraction) *openAPIStub {
+	t.Helper()
+
+	stub := &openAPIStub{servers: map[string]*httptest.Server{}}
+	for service := range serviceEndpointTemplates {
+		server := httptest.NewServer(stub.handler(service))
+		stub.servers[service] = server
+		t.Cleanup(server.Close)
+	}
+
+	stub.add(interactions...)
+	stub.add(&stubInteraction{
+		Service:  "sts",
+		Action:   "GetCallerIdentity",
+		Body:     json.RawMessage(fmt.Sprintf(`{"AccountId": %q, "IdentityType": "Account", "RequestId": "stub"}`, stubAccountId)),
+		Optional: true,
+	})
+	return stub
+}
+
+// add appends interactions, which match after the ones already added
+func (s *openAPIStub) add(interactions ...*stubInteraction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interactions = append(s.interactions, interactions...)
+}
+
+// endpointsConfig returns the "endpoints" connection config argument pointing every service at the stub
+func (s *openAPIStub) endpointsConfig() string {
+	services := make([]string, 0, len(s.servers))
+	for service := range s.servers {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	var b strings.Builder
+	b.WriteString("endpoints = {\n")
+	for _, service := range services {
+		fmt.Fprintf(&b, "  %s = %q\n", service, s.servers[service].URL)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// calls returns the number of requests answered by the interactions of a service and action
+func (s *openAPIStub) calls(service, action string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, interaction := range s.interactions {
+		if interaction.Service == service && interaction.Action == action {
+			count += interaction.calls
+		}
+	}
+	return count
+}
+
+// verify fails the test for requests no interaction matched and for required interactions which were not used up
+func (s *openAPIStub) verify(t *testing.T) {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, request := range s.unmatched {
+		t.Errorf("unexpected request: %s", request)
+	}
+	for _, interaction := range s.interactions {
+		if interaction.Optional {
+			continue
+		}
+		if interaction.calls == 0 || (interaction.Times > 0 && interaction.calls < interaction.Times) {
+			t.Errorf("interaction not used: %s %s %s%s (%d of %d calls)", interaction.Service, interaction.Action, interaction.Method, interaction.Path, interaction.calls, interaction.Times)
+		}
+	}
+}
+
+func (s *openAPIStub) handler(service string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		request, err := parseStubRequest(service, r, body)
+		if err == nil {
+			err = verifyStubSignature(service, r, body)
+		}
+		if err != nil {
+			writeStubError(w, service, http.StatusForbidden, "SignatureDoesNotMatch", err.Error())
+			s.mu.Lock()
+			s.unmatched = append(s.unmatched, fmt.Sprintf("%s %s%s: %v", service, r.Method, r.URL.Path, err))
+			s.mu.Unlock()
+			return
+		}
+
+		interaction := s.match(request)
+		if interaction == nil {
+			writeStubError(w, service, http.StatusBadRequest, "StubNoFixture", "no fixture for "+request.String())
+			return
+		}
+		writeStubResponse(w, service, interaction)
+	}
+}
+
+// match returns the first interaction which matches the request and has calls left, and records the call
+func (s *openAPIStub) match(request stubRequest) *stubInteraction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, interaction := range s.interactions {
+		if interaction.Times > 0 && interaction.calls >= interaction.Times {
+			continue
+		}
+		if !interaction.matches(request) {
+			continue
+		}
+		interaction.calls++
+		return interaction
+	}
+	s.unmatched = append(s.unmatched, request.String())
+	return nil
+}
+
+func (i *stubInteraction) matches(r stubRequest) bool {
+	if i.Service != r.Service {
+		return false
+	}
+	if i.Action != "" && i.Action != r.Action {
+		return false
+	}
+	if i.Method != "" && !strings.EqualFold(i.Method, r.Method) {
+		return false
+	}
+	if i.Bucket != "" && i.Bucket != r.Bucket {
+		return false
+	}
+	if i.Project != "" && i.Project != r.Project {
+		return false
+	}
+	if i.Path != "" {
+		if prefix, ok := strings.CutSuffix(i.Path, "*"); ok {
+			if !strings.HasPrefix(r.Path, prefix) {
+				return false
+			}
+		} else if i.Path != r.Path {
+			return false
+		}
+	}
+	for key, value := range i.Params {
+		values, ok := r.Params[key]
+		if !ok || !slices.Contains(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseStubRequest reads the parts of a request the interactions match on
+func parseStubRequest(service string, r *http.Request, body []byte) (stubRequest, error) {
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return stubRequest{}, err
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return stubRequest{}, err
+		}
+		for key, values := range form {
+			params[key] = append(params[key], values...)
+		}
+	}
+
+	request := stubRequest{
+		Service: service,
+		Action:  r.Header.Get("x-acs-action"),
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Params:  params,
+	}
+
+	switch service {
+	case "oss":
+		// The OSS client uses path style requests for IP endpoints, i.e. /<bucket>/<object>
+		bucket, object, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		request.Bucket = bucket
+		request.Path = "/" + object
+	case "sls":
+		// Project requests are sent to <project>.<endpoint>
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		request.Project = strings.TrimSuffix(strings.TrimSuffix(host, "127.0.0.1"), ".")
+	}
+
+	return request, nil
+}
+
+// verifyStubSignature checks the request is signed with the credentials of the test connections.
+// OpenAPI (RPC and ROA) signatures are verified in full, OSS and SLS ones only for the access key.
+func verifyStubSignature(service string, r *http.Request, body []byte) error {
+	authorization := r.Header.Get("Authorization")
+
+	switch service {
+	case "oss":
+		// OSS4-HMAC-SHA256 Credential=<access key>/<date>/<region>/oss/aliyun_v4_request,...
+		if !strings.HasPrefix(authorization, "OSS4-HMAC-SHA256 Credential="+stubAccessKeyId+"/") {
+			return fmt.Errorf("unexpected OSS authorization %q", authorization)
+		}
+		return nil
+	case "sls":
+		// LOG <access key>:<signature>
+		if !strings.HasPrefix(authorization, "LOG "+stubAccessKeyId+":") {
+			return fmt.Errorf("unexpected SLS authorization %q", authorization)
+		}
+		return nil
+	}
+
+	const algorithm = "ACS3-HMAC-SHA256"
+	fields := map[string]string{}
+	if rest, ok := strings.CutPrefix(authorization, algorithm+" "); ok {
+		for _, field := range strings.Split(rest, ",") {
+			key, value, _ := strings.Cut(field, "=")
+			fields[key] = value
+		}
+	}
+	if fields["Credential"] != stubAccessKeyId {
+		return fmt.Errorf("unexpected OpenAPI authorization %q", authorization)
+	}
+
+	payloadHash := sha256.Sum256(body)
+	if r.Header.Get("x-acs-content-sha256") != hex.EncodeToString(payloadHash[:]) {
+		return fmt.Errorf("x-acs-content-sha256 does not match the request body")
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	var canonicalHeaders strings.Builder
+	for _, header := range signedHeaders {
+		value := r.Header.Get(header)
+		if header == "host" {
+			value = r.Host
+		}
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", header, strings.TrimSpace(value))
+	}
+
+	query := r.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+url.QueryEscape(query.Get(key)))
+	}
+
+	canonicalURI := r.URL.Path
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		acs3Escape(canonicalURI),
+		acs3Escape(strings.Join(pairs, "&")),
+		canonicalHeaders.String(),
+		fields["SignedHeaders"],
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	mac := hmac.New(sha256.New, []byte(stubAccessKeySecret))
+	mac.Write([]byte(algorithm + "\n" + hex.EncodeToString(requestHash[:])))
+	if expected := hex.EncodeToString(mac.Sum(nil)); fields["Signature"] != expected {
+		return fmt.Errorf("signature does not match, canonical request:\n%s", canonicalRequest)
+	}
+	return nil
+}
+
+// acs3Escape applies the escaping of the ACS3 canonical request on top of URL encoding
+func acs3Escape(s string) string {
+	return strings.NewReplacer("+", "%20", "*", "%2A", "%7E", "~").Replace(s)
+}
+
+func writeStubResponse(w http.ResponseWriter, service string, interaction *stubInteraction) {
+	if interaction.Disconnect {
+		if conn, _, err := http.NewResponseController(w).Hijack(); err == nil {
+			conn.Close()
+			return
+		}
+	}
+
+	for key, value := range interaction.Headers {
+		w.Header().Set(key, value)
+	}
+
+	if interaction.ErrorCode != "" {
+		status := interaction.Status
+		if status == 0 {
+			status = http.StatusBadRequest
+		}
+		writeStubError(w, service, status, interaction.ErrorCode, interaction.ErrorMessage)
+		return
+	}
+
+	status := interaction.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	var raw string
+	body := interaction.Body
+	if len(body) > 0 && json.Unmarshal(body, &raw) == nil {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "application/xml")
+		}
+		body = []byte(raw)
+	} else if len(body) == 0 {
+		body = []byte("{}")
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.Header().Set("x-acs-request-id", "stub")
+	w.Header().Set("x-oss-request-id", "stub")
+	w.Header().Set("x-log-requestid", "stub")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// writeStubError writes the error document of a service, which the SDKs parse into their error types
+func writeStubError(w http.ResponseWriter, service string, status int, code, message string) {
+	var body []byte
+	switch service {
+	case "oss":
+		body, _ = xml.Marshal(struct {
+			XMLName   xml.Name `xml:"Error"`
+			Code      string   `xml:"Code"`
+			Message   string   `xml:"Message"`
+			RequestId string   `xml:"RequestId"`
+		}{Code: code, Message: message, RequestId: "stub"})
+		w.Header().Set("Content-Type", "application/xml")
+	case "sls":
+		body, _ = json.Marshal(map[string]string{"errorCode": code, "errorMessage": message})
+		w.Header().Set("Content-Type", "application/json")
+	default:
+		body, _ = json.Marshal(map[string]string{"Code": code, "Message": message, "RequestId": "stub"})
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.Header().Set("x-acs-request-id", "stub")
+	w.Header().Set("x-oss-request-id", "stub")
+	w.Header().Set("x-log-requestid", "stub")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -21,12 +21,6 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		DefaultIgnoreConfig: &plugin.IgnoreConfig{
 			ShouldIgnoreErrorFunc: shouldIgnoreErrorPluginDefault(),
 		},
-		ConnectionKeyColumns: []plugin.ConnectionKeyColumn{
-			{
-				Name:    "account_id",
-				Hydrate: getAccountId,
-			},
-		},
 		ConnectionConfigSchema: &plugin.ConnectionConfigSchema{
 			NewInstance: ConfigInstance,
 		},
@@ -35,6 +29,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"alicloud_action_trail":                               tableAlicloudActionTrail(ctx),
 			"alicloud_alidns_domain":                              tableAlicloudAlidnsDomain(ctx),
 			"alicloud_cas_certificate":                            tableAlicloudUserCertificate(ctx),
+			"alicloud_cms_metric":                                 tableAlicloudCmsMetric(ctx),
 			"alicloud_cms_monitor_host":                           tableAlicloudCmsMonitorHost(ctx),
 			"alicloud_cs_kubernetes_cluster":                      tableAlicloudCsKubernetesCluster(ctx),
 			"alicloud_cs_kubernetes_cluster_node":                 tableAlicloudCsKubernetesClusterNode(ctx),
@@ -56,19 +51,27 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"alicloud_ecs_network_interface":                      tableAlicloudEcsEni(ctx),
 			"alicloud_ecs_region":                                 tableAlicloudEcsRegion(ctx),
 			"alicloud_ecs_security_group":                         tableAlicloudEcsSecurityGroup(ctx),
+			"alicloud_ecs_security_group_rule":                    tableAlicloudEcsSecurityGroupRule(ctx),
 			"alicloud_ecs_snapshot":                               tableAlicloudEcsSnapshot(ctx),
 			"alicloud_ecs_zone":                                   tableAlicloudEcsZone(ctx),
 			"alicloud_fc_function":                                tableAlicloudFcFunction(ctx),
 			"alicloud_kms_key":                                    tableAlicloudKmsKey(ctx),
 			"alicloud_kms_secret":                                 tableAlicloudKmsSecret(ctx),
 			"alicloud_oss_bucket":                                 tableAlicloudOssBucket(ctx),
+			"alicloud_plugin_permission":                          tableAlicloudPluginPermission(ctx),
+			"alicloud_plugin_permission_policy":                   tableAlicloudPluginPermissionPolicy(ctx),
+			"alicloud_query_diagnostic":                           tableAlicloudQueryDiagnostic(ctx),
 			"alicloud_ram_access_key":                             tableAlicloudRAMAccessKey(ctx),
 			"alicloud_ram_credential_report":                      tableAlicloudRAMCredentialReport(ctx),
+			"alicloud_ram_effective_permission":                   tableAlicloudRamEffectivePermission(ctx),
 			"alicloud_ram_group":                                  tableAlicloudRAMGroup(ctx),
 			"alicloud_ram_password_policy":                        tableAlicloudRamPasswordPolicy(ctx),
 			"alicloud_ram_policy":                                 tableAlicloudRamPolicy(ctx),
+			"alicloud_ram_policy_statement":                       tableAlicloudRamPolicyStatement(ctx),
+			"alicloud_ram_policy_version":                         tableAlicloudRamPolicyVersion(ctx),
 			"alicloud_ram_role":                                   tableAlicloudRAMRole(ctx),
 			"alicloud_ram_security_preference":                    tableAlicloudRAMSecurityPreference(ctx),
+			"alicloud_ram_sso_setting":                            tableAlicloudRAMSsoSetting(ctx),
 			"alicloud_ram_user":                                   tableAlicloudRAMUser(ctx),
 			"alicloud_rds_backup":                                 tableAlicloudRdsBackup(ctx),
 			"alicloud_rds_database":                               tableAlicloudRdsDatabase(ctx),
@@ -93,6 +96,8 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"alicloud_vpc_flow_log":                               tableAlicloudVpcFlowLog(ctx),
 			"alicloud_vpc_nat_gateway":                            tableAlicloudVpcNatGateway(ctx),
 			"alicloud_vpc_network_acl":                            tableAlicloudVpcNetworkACL(ctx),
+			"alicloud_vpc_network_acl_entry":                      tableAlicloudVpcNetworkACLEntry(ctx),
+			"alicloud_vpc_network_acl_reachability":               tableAlicloudVpcNetworkACLReachability(ctx),
 			"alicloud_vpc_route_entry":                            tableAlicloudVpcRouteEntry(ctx),
 			"alicloud_vpc_route_table":                            tableAlicloudVpcRouteTable(ctx),
 			"alicloud_vpc_ssl_vpn_client_cert":                    tableAlicloudVpcSslVpnClientCert(ctx),
@@ -103,5 +108,6 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"alicloud_vpc_vswitch":                                tableAlicloudVpcVSwitch(ctx),
 		},
 	}
+	nameHydrateIgnoreConfigs(p)
 	return p
 }
@@ -0,0 +1,243 @@
+package alicloud
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Decisions of a RAM permission evaluation
+const (
+	ramDecisionAllowed      = "allowed"
+	ramDecisionExplicitDeny = "explicit_deny"
+	ramDecisionImplicitDeny = "implicit_deny"
+)
+
+// Results of the conditions of a statement
+const (
+	ramConditionMet     = "met"
+	ramConditionNotMet  = "not_met"
+	ramConditionUnknown = "unknown"
+)
+
+// ramAttachedPolicy is a policy attached to a principal, directly or through a group
+type ramAttachedPolicy struct {
+	PolicyName string
+	PolicyType string
+	VersionId  string
+	// How the policy is attached to the principal, e.g. "user", "role" or "group/admins"
+	AttachedVia string
+	Document    Policy
+}
+
+// ramMatchedStatement is a statement which applies to the action and resource of an evaluation
+type ramMatchedStatement struct {
+	PolicyName     string
+	PolicyType     string
+	AttachedVia    string
+	StatementIndex int
+	Sid            string `json:",omitempty"`
+	Effect         string
+	// Whether the conditions of the statement are met, or unknown if the evaluation context lacks some condition keys
+	ConditionResult string
+}
+
+// ramPermissionEvaluation is the result of evaluating the policies of a principal for an action on a resource
+type ramPermissionEvaluation struct {
+	Decision string
+	// True if the decision could change with the values of condition keys missing from the evaluation context
+	Conditional       bool
+	MatchedStatements []ramMatchedStatement
+}
+
+// evaluateRAMPermission evaluates policies the way RAM does: an explicit Deny overrides any Allow,
+// and an action which no statement allows is denied. Statements whose conditions depend on keys
+// missing from the context are ignored, and make the decision conditional.
+func evaluateRAMPermission(policies []ramAttachedPolicy, action string, resource string, context map[string][]string) ramPermissionEvaluation {
+	evaluation := ramPermissionEvaluation{
+		Decision:          ramDecisionImplicitDeny,
+		MatchedStatements: []ramMatchedStatement{},
+	}
+
+	var allowed, denied, unknownAllow, unknownDeny bool
+	for _, policy := range policies {
+		for i, statement := range policy.Document.Statements {
+			if !statementMatchesAction(statement, action) || !statementMatchesResource(statement, resource) {
+				continue
+			}
+
+			result := evaluateRAMConditions(statement.Condition, context)
+			evaluation.MatchedStatements = append(evaluation.MatchedStatements, ramMatchedStatement{
+				PolicyName:      policy.PolicyName,
+				PolicyType:      policy.PolicyType,
+				AttachedVia:     policy.AttachedVia,
+				StatementIndex:  i,
+				Sid:             statement.Sid,
+				Effect:          statement.Effect,
+				ConditionResult: result,
+			})
+
+			deny := strings.EqualFold(statement.Effect, "Deny")
+			switch {
+			case result == ramConditionNotMet:
+			case deny && result == ramConditionMet:
+				denied = true
+			case deny:
+				unknownDeny = true
+			case result == ramConditionMet:
+				allowed = true
+			default:
+				unknownAllow = true
+			}
+		}
+	}
+
+	switch {
+	case denied:
+		evaluation.Decision = ramDecisionExplicitDeny
+	case allowed:
+		evaluation.Decision = ramDecisionAllowed
+		evaluation.Conditional = unknownDeny
+	default:
+		evaluation.Conditional = unknownAllow
+	}
+	return evaluation
+}
+
+// statementMatchesResource returns true if a statement applies to a resource. Resources are case sensitive.
+func statementMatchesResource(statement Statement, resource string) bool {
+	if len(statement.NotResource) > 0 {
+		return !matchesAnyPolicyPattern(statement.NotResource, resource)
+	}
+	return matchesAnyPolicyPattern(statement.Resource, resource)
+}
+
+// evaluateRAMConditions evaluates the conditions of a statement, in the canonical form of canonicalCondition.
+// All conditions must be met. A condition is met if any value of its key in the context matches any of its values.
+func evaluateRAMConditions(conditions map[string]interface{}, context map[string][]string) string {
+	result := ramConditionMet
+	for operator, condition := range conditions {
+		keys, ok := condition.(map[string]interface{})
+		if !ok {
+			return ramConditionUnknown
+		}
+		for key, values := range keys {
+			conditionValues, ok := values.([]string)
+			if !ok {
+				return ramConditionUnknown
+			}
+			contextValues, ok := context[strings.ToLower(key)]
+			if !ok {
+				result = ramConditionUnknown
+				continue
+			}
+			switch evaluateRAMCondition(operator, conditionValues, contextValues) {
+			case ramConditionNotMet:
+				return ramConditionNotMet
+			case ramConditionUnknown:
+				result = ramConditionUnknown
+			}
+		}
+	}
+	return result
+}
+
+// evaluateRAMCondition evaluates a condition operator, e.g. StringEquals or IpAddress. Unsupported operators are unknown.
+func evaluateRAMCondition(operator string, conditionValues []string, contextValues []string) string {
+	operator = strings.ToLower(operator)
+
+	var negate bool
+	var match func(conditionValue string, contextValue string) bool
+	switch operator {
+	case "stringequals", "stringnotequals":
+		negate = operator == "stringnotequals"
+		match = func(c, v string) bool { return c == v }
+	case "stringequalsignorecase", "stringnotequalsignorecase":
+		negate = operator == "stringnotequalsignorecase"
+		match = strings.EqualFold
+	case "stringlike", "stringnotlike":
+		negate = operator == "stringnotlike"
+		match = matchPolicyPattern
+	case "bool":
+		match = strings.EqualFold
+	case "ipaddress", "notipaddress":
+		negate = operator == "notipaddress"
+		match = func(c, v string) bool {
+			prefix, err := parseCidrOrAddress(c)
+			if err != nil {
+				return false
+			}
+			addr, err := netip.ParseAddr(v)
+			return err == nil && prefix.Contains(addr)
+		}
+	default:
+		// Like the string operators, NumericNotEquals and DateNotEquals are met if no value is equal
+		if strings.HasSuffix(operator, "notequals") {
+			negate = true
+			operator = strings.TrimSuffix(operator, "notequals") + "equals"
+		}
+		compare, ok := ramConditionComparators[operator]
+		if !ok {
+			return ramConditionUnknown
+		}
+		parse := parseRAMConditionNumber
+		if strings.HasPrefix(operator, "date") {
+			parse = parseRAMConditionDate
+		}
+		match = func(c, v string) bool {
+			conditionValue, err := parse(c)
+			if err != nil {
+				return false
+			}
+			contextValue, err := parse(v)
+			if err != nil {
+				return false
+			}
+			return compare(contextValue, conditionValue)
+		}
+	}
+
+	for _, contextValue := range contextValues {
+		for _, conditionValue := range conditionValues {
+			if match(conditionValue, contextValue) {
+				if negate {
+					return ramConditionNotMet
+				}
+				return ramConditionMet
+			}
+		}
+	}
+	if negate {
+		return ramConditionMet
+	}
+	return ramConditionNotMet
+}
+
+// Numeric and date condition operators, comparing the context value with the condition value
+var ramConditionComparators = map[string]func(float64, float64) bool{
+	"numericequals":            func(a, b float64) bool { return a == b },
+	"numericlessthan":          func(a, b float64) bool { return a < b },
+	"numericlessthanequals":    func(a, b float64) bool { return a <= b },
+	"numericgreaterthan":       func(a, b float64) bool { return a > b },
+	"numericgreaterthanequals": func(a, b float64) bool { return a >= b },
+	"dateequals":               func(a, b float64) bool { return a == b },
+	"datelessthan":             func(a, b float64) bool { return a < b },
+	"datelessthanequals":       func(a, b float64) bool { return a <= b },
+	"dategreaterthan":          func(a, b float64) bool { return a > b },
+	"dategreaterthanequals":    func(a, b float64) bool { return a >= b },
+}
+
+func parseRAMConditionNumber(value string) (float64, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+// parseRAMConditionDate parses an ISO 8601 date, e.g. 2019-08-12T17:00:00+08:00, as seconds since the epoch
+func parseRAMConditionDate(value string) (float64, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05Z0700", "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return float64(t.Unix()), nil
+		}
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,300 @@
+package alicloud
+
+import (
+	"testing"
+)
+
+// testRAMPolicy returns an attached policy with a document in the canonical form the table evaluates
+func testRAMPolicy(t *testing.T, name string, document string) ramAttachedPolicy {
+	t.Helper()
+	policy, err := canonicalPolicy(document)
+	if err != nil {
+		t.Fatalf("invalid policy %s: %v", name, err)
+	}
+	return ramAttachedPolicy{PolicyName: name, PolicyType: "Custom", VersionId: "v1", AttachedVia: "user", Document: policy.(Policy)}
+}
+
+func TestEvaluateRAMPermission(t *testing.T) {
+	const instance = "acs:ecs:cn-hangzhou:1234567890123456:instance/i-1"
+
+	cases := []struct {
+		name     string
+		policies []string
+		action   string
+		resource string
+		context  map[string][]string
+
+		decision    string
+		conditional bool
+		// matched is the number of statements which apply to the action and resource
+		matched int
+	}{
+		{
+			name:     "no statement allows the action",
+			policies: []string{`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ecs:Describe*", "Resource": "*"}]}`},
+			action:   "ecs:DeleteInstance",
+			resource: instance,
+			decision: ramDecisionImplicitDeny,
+		},
+		{
+			name:     "action wildcard, actions are case insensitive",
+			policies: []string{`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ECS:Delete*", "Resource": "*"}]}`},
+			action:   "ecs:deleteinstance",
+			resource: instance,
+			decision: ramDecisionAllowed,
+			matched:  1,
+		},
+		{
+			name:     "resource wildcard",
+			policies: []string{`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ecs:*", "Resource": "acs:ecs:*:*:instance/i-?"}]}`},
+			action:   "ecs:DeleteInstance",
+			resource: instance,
+			decision: ramDecisionAllowed,
+			matched:  1,
+		},
+		{
+			name:     "resources are case sensitive",
+			policies: []string{`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ecs:*", "Resource": "acs:ecs:*:*:instance/I-1"}]}`},
+			action:   "ecs:DeleteInstance",
+			resource: instance,
+			decision: ramDecisionImplicitDeny,
+		},
+		{
+			name: "explicit deny overrides allow in another policy",
+			policies: []string{
+				`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "*", "Resource": "*"}]}`,
+				`{"Version": "1", "Statement": [{"Effect": "Deny", "Action": "ecs:DeleteInstance", "Resource": "*"}]}`,
+			},
+			action:   "ecs:DeleteInstance",
+			resource: instance,
+			decision: ramDecisionExplicitDeny,
+			matched:  2,
+		},
+		{
+			name:     "NotAction allows the other actions",
+			policies: []string{`{"Version": "1", "Statement": [{"Effect": "Allow", "NotAction": "ram:*", "Resource": "*"}]}`},
+			action:   "ecs:DeleteInstance",
+			resource: instance,
+			decision: ramDecisionAllowed,
+			matched:  1,
+		},
+		{
+			name:     "NotAction excludes the actions it lists",
+			policies: []string{`{"Version": "1", "Statement": [{"Effect": "Allow", "NotAction": "ram:*", "Resource": "*"}]}`},
+			action:   "ram:CreateUser",
+			resource: "acs:ram:*:1234567890123456:user/*",
+			decision: ramDecisionImplicitDeny,
+		},
+		{
+			name: "NotResource denies the other resources",
+			policies: []string{
+				`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ecs:*", "Resource": "*"}]}`,
+				`{"Version": "1", "Statement": [{"Effect": "Deny", "Action": "ecs:*", "NotResource": "acs:ecs:cn-shanghai:*:*"}]}`,
+			},
+			action:   "ecs:DeleteInstance",
+			resource: instance,
+			decision: ramDecisionExplicitDeny,
+			matched:  2,
+		},
+		{
+			name: "NotResource does not apply to the resources it lists",
+			policies: []string{
+				`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ecs:*", "Resource": "*"}]}`,
+				`{"Version": "1", "Statement": [{"Effect": "Deny", "Action": "ecs:*", "NotResource": "acs:ecs:cn-hangzhou:*:*"}]}`,
+			},
+			action:   "ecs:DeleteInstance",
+			resource: instance,
+			decision: ramDecisionAllowed,
+			matched:  1,
+		},
+		{
+			name:     "allow with a condition which is met",
+			policies: []string{`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ecs:*", "Resource": "*", "Condition": {"IpAddress": {"acs:SourceIp": "203.0.113.0/24"}}}]}`},
+			action:   "ecs:DeleteInstance",
+			resource: instance,
+			context:  map[string][]string{"acs:sourceip": {"203.0.113.10"}},
+			decision: ramDecisionAllowed,
+			matched:  1,
+		},
+		{
+			name:     "allow with a condition which is not met",
+			policies: []string{`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ecs:*", "Resource": "*", "Condition": {"IpAddress": {"acs:SourceIp": "203.0.113.0/24"}}}]}`},
+			action:   "ecs:DeleteInstance",
+			resource: instance,
+			context:  map[string][]string{"acs:sourceip": {"198.51.100.1"}},
+			decision: ramDecisionImplicitDeny,
+			matched:  1,
+		},
+		{
+			name:        "allow with a condition key missing from the context",
+			policies:    []string{`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ecs:*", "Resource": "*", "Condition": {"Bool": {"acs:MFAPresent": "true"}}}]}`},
+			action:      "ecs:DeleteInstance",
+			resource:    instance,
+			decision:    ramDecisionImplicitDeny,
+			conditional: true,
+			matched:     1,
+		},
+		{
+			name: "deny with a condition key missing from the context",
+			policies: []string{
+				`{"Version": "1", "Statement": [{"Effect": "Allow", "Action": "ecs:*", "Resource": "*"}]}`,
+				`{"Version": "1", "Statement": [{"Effect": "Deny", "Action": "ecs:*", "Resource": "*", "Condition": {"Bool": {"acs:MFAPresent": "false"}}}]}`,
+			},
+			action:      "ecs:DeleteInstance",
+			resource:    instance,
+			decision:    ramDecisionAllowed,
+			conditional: true,
+			matched:     2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var policies []ramAttachedPolicy
+			for _, document := range tc.policies {
+				policies = append(policies, testRAMPolicy(t, "policy", document))
+			}
+
+			evaluation := evaluateRAMPermission(policies, tc.action, tc.resource, tc.context)
+			if evaluation.Decision != tc.decision {
+				t.Errorf("expected decision %s, got %s", tc.decision, evaluation.Decision)
+			}
+			if evaluation.Conditional != tc.conditional {
+				t.Errorf("expected conditional %t, got %t", tc.conditional, evaluation.Conditional)
+			}
+			if len(evaluation.MatchedStatements) != tc.matched {
+				t.Errorf("expected %d matched statements, got %+v", tc.matched, evaluation.MatchedStatements)
+			}
+		})
+	}
+}
+
+func TestMatchPolicyPattern(t *testing.T) {
+	cases := []struct {
+		pattern string
+		value   string
+		match   bool
+	}{
+		{"*", "", true},
+		{"*", "ecs:deleteinstance", true},
+		{"ecs:*", "ecs:deleteinstance", true},
+		{"ecs:*", "ram:createuser", false},
+		{"ecs:delete*", "ecs:deleteinstance", true},
+		{"ecs:*instance", "ecs:deleteinstance", true},
+		{"ecs:*instance", "ecs:deleteinstances", false},
+		{"*:*", "*:*", true},
+		{"ram:*", "*:*", false},
+		{"i-?", "i-1", true},
+		{"i-?", "i-12", false},
+		{"acs:oss:*:*:bucket/*/logs/*", "acs:oss:*:1234567890123456:bucket/b/logs/2024/01", true},
+		{"acs:oss:*:*:bucket/*/logs/*", "acs:oss:*:1234567890123456:bucket/b/data/logs", false},
+		{"Bucket", "bucket", false},
+		{"", "", true},
+		{"", "a", false},
+	}
+
+	for _, tc := range cases {
+		if match := matchPolicyPattern(tc.pattern, tc.value); match != tc.match {
+			t.Errorf("matchPolicyPattern(%q, %q) = %t, expected %t", tc.pattern, tc.value, match, tc.match)
+		}
+	}
+}
+
+func TestEvaluateRAMCondition(t *testing.T) {
+	cases := []struct {
+		operator        string
+		conditionValues []string
+		contextValues   []string
+		result          string
+	}{
+		{"StringEquals", []string{"prod", "test"}, []string{"test"}, ramConditionMet},
+		{"StringEquals", []string{"prod"}, []string{"Prod"}, ramConditionNotMet},
+		{"StringNotEquals", []string{"prod"}, []string{"test"}, ramConditionMet},
+		{"StringNotEquals", []string{"prod"}, []string{"prod"}, ramConditionNotMet},
+		{"StringEqualsIgnoreCase", []string{"prod"}, []string{"PROD"}, ramConditionMet},
+		{"StringNotEqualsIgnoreCase", []string{"prod"}, []string{"PROD"}, ramConditionNotMet},
+		{"StringLike", []string{"team-*"}, []string{"team-a"}, ramConditionMet},
+		{"StringLike", []string{"team-?"}, []string{"team-ab"}, ramConditionNotMet},
+		{"StringNotLike", []string{"team-*"}, []string{"ops"}, ramConditionMet},
+		{"Bool", []string{"true"}, []string{"TRUE"}, ramConditionMet},
+		{"Bool", []string{"true"}, []string{"false"}, ramConditionNotMet},
+		{"IpAddress", []string{"203.0.113.0/24"}, []string{"203.0.113.10"}, ramConditionMet},
+		{"IpAddress", []string{"203.0.113.10"}, []string{"203.0.113.10"}, ramConditionMet},
+		{"IpAddress", []string{"203.0.113.0/24"}, []string{"198.51.100.1"}, ramConditionNotMet},
+		{"IpAddress", []string{"203.0.113.0/24"}, []string{"not-an-ip"}, ramConditionNotMet},
+		{"NotIpAddress", []string{"203.0.113.0/24"}, []string{"198.51.100.1"}, ramConditionMet},
+		{"NotIpAddress", []string{"203.0.113.0/24"}, []string{"203.0.113.10"}, ramConditionNotMet},
+		{"NumericEquals", []string{"10"}, []string{"10.0"}, ramConditionMet},
+		{"NumericNotEquals", []string{"10"}, []string{"11"}, ramConditionMet},
+		{"NumericLessThan", []string{"10"}, []string{"9"}, ramConditionMet},
+		{"NumericLessThanEquals", []string{"10"}, []string{"10"}, ramConditionMet},
+		{"NumericGreaterThan", []string{"10"}, []string{"10"}, ramConditionNotMet},
+		{"NumericGreaterThanEquals", []string{"10"}, []string{"10"}, ramConditionMet},
+		{"DateLessThan", []string{"2019-08-12T17:00:00+08:00"}, []string{"2019-08-12T08:59:59Z"}, ramConditionMet},
+		{"DateGreaterThan", []string{"2019-08-12T17:00:00+08:00"}, []string{"2019-08-12T08:59:59Z"}, ramConditionNotMet},
+		{"DateEquals", []string{"2019-08-12"}, []string{"2019-08-12T00:00:00Z"}, ramConditionMet},
+		{"DateNotEquals", []string{"2019-08-12"}, []string{"2019-08-13"}, ramConditionMet},
+		{"ArnLike", []string{"acs:ram::*:role/*"}, []string{"acs:ram::1234567890123456:role/admin"}, ramConditionUnknown},
+	}
+
+	for _, tc := range cases {
+		if result := evaluateRAMCondition(tc.operator, tc.conditionValues, tc.contextValues); result != tc.result {
+			t.Errorf("evaluateRAMCondition(%s, %v, %v) = %s, expected %s", tc.operator, tc.conditionValues, tc.contextValues, result, tc.result)
+		}
+	}
+}
+
+func TestEvaluateRAMConditions(t *testing.T) {
+	conditions := map[string]interface{}{
+		"StringEquals": map[string]interface{}{"acs:CurrentVersion": []string{"2022"}},
+		"IpAddress":    map[string]interface{}{"acs:SourceIp": []string{"203.0.113.0/24"}},
+	}
+
+	cases := []struct {
+		name    string
+		context map[string][]string
+		result  string
+	}{
+		{"all conditions are met", map[string][]string{"acs:currentversion": {"2022"}, "acs:sourceip": {"203.0.113.10"}}, ramConditionMet},
+		{"one condition is not met", map[string][]string{"acs:currentversion": {"2022"}, "acs:sourceip": {"198.51.100.1"}}, ramConditionNotMet},
+		{"a key is missing", map[string][]string{"acs:currentversion": {"2022"}}, ramConditionUnknown},
+		{"a key is missing and another condition is not met", map[string][]string{"acs:currentversion": {"2023"}}, ramConditionNotMet},
+	}
+
+	for _, tc := range cases {
+		if result := evaluateRAMConditions(conditions, tc.context); result != tc.result {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.result, result)
+		}
+	}
+}
+
+func TestParseRAMPrincipal(t *testing.T) {
+	cases := []struct {
+		principal     string
+		accountId     string
+		principalType string
+		name          string
+		err           bool
+	}{
+		{principal: "alice", principalType: "user", name: "alice"},
+		{principal: "user/alice", principalType: "user", name: "alice"},
+		{principal: "Role/admin", principalType: "role", name: "admin"},
+		{principal: "acs:ram::1234567890123456:user/alice", accountId: "1234567890123456", principalType: "user", name: "alice"},
+		{principal: "acs:ram::1234567890123456:role/admin", accountId: "1234567890123456", principalType: "role", name: "admin"},
+		{principal: "group/admins", err: true},
+		{principal: "acs:ram:::user/alice", err: true},
+	}
+
+	for _, tc := range cases {
+		accountId, principalType, name, err := parseRAMPrincipal(tc.principal)
+		if tc.err {
+			if err == nil {
+				t.Errorf("parseRAMPrincipal(%q): expected an error", tc.principal)
+			}
+			continue
+		}
+		if err != nil || accountId != tc.accountId || principalType != tc.principalType || name != tc.name {
+			t.Errorf("parseRAMPrincipal(%q) = %q, %q, %q, %v", tc.principal, accountId, principalType, name, err)
+		}
+	}
+}
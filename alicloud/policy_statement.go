@@ -0,0 +1,117 @@
+package alicloud
+
+import (
+	"strings"
+)
+
+// RAM actions which let a principal grant itself, or a principal it controls, more permissions
+// than it has, e.g. by attaching a policy to itself or by passing a more privileged role to a service
+var ramPrivilegeEscalationActions = []string{
+	"ram:addusertogroup",
+	"ram:attachpolicytogroup",
+	"ram:attachpolicytorole",
+	"ram:attachpolicytouser",
+	"ram:createaccesskey",
+	"ram:createloginprofile",
+	"ram:createpolicyversion",
+	"ram:passrole",
+	"ram:setdefaultpolicyversion",
+	"ram:updateloginprofile",
+	"ram:updaterole",
+	"sts:assumerole",
+}
+
+// isAllowStatement returns true for statements which grant permissions
+func isAllowStatement(statement Statement) bool {
+	return strings.EqualFold(statement.Effect, "Allow")
+}
+
+// statementMatchesAction returns true if a statement applies to an action, ignoring its resources and conditions
+func statementMatchesAction(statement Statement, action string) bool {
+	action = strings.ToLower(action)
+	if len(statement.NotAction) > 0 {
+		return !matchesAnyPolicyPattern(statement.NotAction, action)
+	}
+	return matchesAnyPolicyPattern(statement.Action, action)
+}
+
+// statementAllowsAllActions returns true for Allow statements whose actions include * or *:*
+func statementAllowsAllActions(statement Statement) bool {
+	// The "*" of "*:*" is matched literally, so only patterns which match every action match it
+	return isAllowStatement(statement) && matchesAnyPolicyPattern(statement.Action, "*:*")
+}
+
+// statementAllowsAllServiceActions returns true for Allow statements which apply to every action of a service, e.g. ram:*
+func statementAllowsAllServiceActions(statement Statement, service string) bool {
+	if !isAllowStatement(statement) {
+		return false
+	}
+	if len(statement.NotAction) > 0 {
+		for _, pattern := range statement.NotAction {
+			patternService, _, _ := strings.Cut(pattern, ":")
+			if matchPolicyPattern(patternService, service) {
+				return false
+			}
+		}
+		return true
+	}
+	// The "*" of "service:*" is matched literally, so only patterns which match every action of the service match it
+	return matchesAnyPolicyPattern(statement.Action, service+":*")
+}
+
+// statementAllowsAllResources returns true for Allow statements whose resources include *
+func statementAllowsAllResources(statement Statement) bool {
+	return isAllowStatement(statement) && matchesAnyPolicyPattern(statement.Resource, "*")
+}
+
+// statementPrivilegeEscalationActions returns the privilege escalation actions an Allow statement applies to
+func statementPrivilegeEscalationActions(statement Statement) []string {
+	actions := []string{}
+	if !isAllowStatement(statement) {
+		return actions
+	}
+	for _, action := range ramPrivilegeEscalationActions {
+		if statementMatchesAction(statement, action) {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// matchesAnyPolicyPattern returns true if the value matches any of the patterns
+func matchesAnyPolicyPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matchPolicyPattern(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPolicyPattern matches a value against a RAM policy pattern, in which "*" matches any sequence
+// of characters and "?" matches any single character. The match is case sensitive.
+func matchPolicyPattern(pattern string, value string) bool {
+	p, v := 0, 0
+	// Position of the last "*" in the pattern, and of the value when it was reached
+	star, match := -1, 0
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, match = p, v
+			p++
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]):
+			p++
+			v++
+		case star >= 0:
+			// Let the last "*" match one more character
+			match++
+			p, v = star+1, match
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
@@ -0,0 +1,301 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestMain routes every HTTP request of the tests through a local proxy, which only forwards
+// requests to the stub servers. SLS sends project requests to <project>.<endpoint>, which the
+// proxy resolves to the stub, and any request to a real endpoint fails instead of reaching the network.
+func TestMain(m *testing.M) {
+	proxy := httptest.NewServer(stubProxyHandler())
+
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "http_proxy", "https_proxy"} {
+		os.Setenv(name, proxy.URL)
+	}
+	for _, name := range []string{"NO_PROXY", "no_proxy"} {
+		os.Unsetenv(name)
+	}
+	if _, ok := os.LookupEnv("STEAMPIPE_LOG_LEVEL"); !ok {
+		os.Setenv("STEAMPIPE_LOG_LEVEL", "off")
+	}
+	// Retries of the stub do not need to back off. The OpenAPI clients still wait a second, their shortest delay.
+	retryBaseDelay = 10 * time.Millisecond
+
+	code := m.Run()
+	proxy.Close()
+	os.Exit(code)
+}
+
+// stubProxyHandler forwards requests for 127.0.0.1 and its subdomains, keeping the Host header
+func stubProxyHandler() http.Handler {
+	forward := &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			_, port, _ := net.SplitHostPort(r.URL.Host)
+			r.URL.Scheme = "http"
+			r.URL.Host = net.JoinHostPort("127.0.0.1", port)
+		},
+		Transport: &http.Transport{Proxy: nil},
+		// A connection closed by the stub reaches the client as a connection error, not as a 502 response
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			if conn, _, hijackErr := http.NewResponseController(w).Hijack(); hijackErr == nil {
+				conn.Close()
+				return
+			}
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.URL.Host)
+		if err != nil {
+			host = r.URL.Host
+		}
+		if r.Method == http.MethodConnect || (host != "127.0.0.1" && !strings.HasSuffix(host, ".127.0.0.1")) {
+			http.Error(w, "network access is disabled in tests: "+r.URL.Host, http.StatusBadGateway)
+			return
+		}
+		forward.ServeHTTP(w, r)
+	})
+}
+
+// testConnection is a plugin instance with a single connection, queried in process
+type testConnection struct {
+	t      *testing.T
+	server *grpc.PluginServer
+	name   string
+	schema map[string]*proto.TableSchema
+}
+
+var testConnectionCount atomic.Int64
+
+// newTestConnection creates a plugin instance with a connection pointed at the stub.
+// The config is appended to the credentials and endpoints set by the harness.
+// Every connection has its own plugin instance, so nothing is shared through the connection cache.
+func newTestConnection(t *testing.T, stub *openAPIStub, config string) *testConnection {
+	t.Helper()
+
+	server := plugin.Server(&plugin.ServeOpts{PluginFunc: Plugin})
+	// State kept by the plugin process per connection, e.g. the ignored errors, is not shared between tests
+	name := fmt.Sprintf("alicloud_test_%d", testConnectionCount.Add(1))
+
+	hcl := fmt.Sprintf("access_key = %q\nsecret_key = %q\n%s%s\n", stubAccessKeyId, stubAccessKeySecret, stub.endpointsConfig(), config)
+	response, err := server.SetAllConnectionConfigs(&proto.SetAllConnectionConfigsRequest{
+		Configs: []*proto.ConnectionConfig{{
+			Connection:      name,
+			Plugin:          "alicloud",
+			PluginShortName: "alicloud",
+			Config:          hcl,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to set the connection config: %v", err)
+	}
+	if failure, ok := response.FailedConnections[name]; ok {
+		t.Fatalf("failed to set the connection config: %s", failure)
+	}
+
+	schema, err := server.GetSchema(&proto.GetSchemaRequest{Connection: name})
+	if err != nil {
+		t.Fatalf("failed to get the plugin schema: %v", err)
+	}
+
+	return &testConnection{t: t, server: server, name: name, schema: schema.Schema.Schema}
+}
+
+// testQual is a qual of a test query, e.g. name = 'alice'
+type testQual struct {
+	Column   string `json:"column"`
+	Operator string `json:"operator,omitempty"`
+	Value    any    `json:"value"`
+}
+
+// query runs a query against a table and returns its rows, with the column values in their JSON form:
+// numbers as float64, timestamps as RFC 3339 strings and JSON columns decoded.
+func (c *testConnection) query(table string, columns []string, quals []testQual, limit int64) ([]map[string]any, error) {
+	c.t.Helper()
+
+	tableSchema, ok := c.schema[table]
+	if !ok {
+		return nil, fmt.Errorf("table %s does not exist", table)
+	}
+
+	qualMap := map[string]*proto.Quals{}
+	for _, qual := range quals {
+		value, err := qualValue(tableSchema, qual)
+		if err != nil {
+			return nil, err
+		}
+		operator := qual.Operator
+		if operator == "" {
+			operator = "="
+		}
+		if qualMap[qual.Column] == nil {
+			qualMap[qual.Column] = &proto.Quals{}
+		}
+		qualMap[qual.Column].Quals = append(qualMap[qual.Column].Quals, &proto.Qual{
+			FieldName: qual.Column,
+			Operator:  &proto.Qual_StringValue{StringValue: operator},
+			Value:     value,
+		})
+	}
+
+	queryContext := &proto.QueryContext{Columns: columns, Quals: qualMap}
+	if limit > 0 {
+		queryContext.Limit = &proto.NullableInt{Value: limit}
+	}
+
+	stream := &rowCollector{ctx: context.Background()}
+	err := c.server.Execute(&proto.ExecuteRequest{
+		Table:        table,
+		QueryContext: queryContext,
+		CallId:       fmt.Sprintf("%s-%d", c.t.Name(), time.Now().UnixNano()),
+		Connection:   c.name,
+		ExecuteConnectionData: map[string]*proto.ExecuteConnectionData{
+			c.name: {Limit: queryContext.Limit, CacheEnabled: false},
+		},
+	}, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]any, 0, len(stream.rows))
+	for _, row := range stream.rows {
+		values := map[string]any{}
+		for name, column := range row.Columns {
+			value, err := columnValue(column)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %v", name, err)
+			}
+			values[name] = value
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+// qualValue converts a qual value to the type of its column
+func qualValue(table *proto.TableSchema, qual testQual) (*proto.QualValue, error) {
+	var columnType proto.ColumnType
+	found := false
+	for _, column := range table.Columns {
+		if column.Name == qual.Column {
+			columnType, found = column.Type, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("column %s does not exist", qual.Column)
+	}
+
+	switch value := qual.Value.(type) {
+	case string:
+		switch columnType {
+		case proto.ColumnType_STRING:
+			return &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: value}}, nil
+		case proto.ColumnType_TIMESTAMP:
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, err
+			}
+			return &proto.QualValue{Value: &proto.QualValue_TimestampValue{TimestampValue: timestamppb.New(t)}}, nil
+		}
+	case float64:
+		switch columnType {
+		case proto.ColumnType_INT:
+			return &proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: int64(value)}}, nil
+		case proto.ColumnType_DOUBLE:
+			return &proto.QualValue{Value: &proto.QualValue_DoubleValue{DoubleValue: value}}, nil
+		}
+	case bool:
+		if columnType == proto.ColumnType_BOOL {
+			return &proto.QualValue{Value: &proto.QualValue_BoolValue{BoolValue: value}}, nil
+		}
+	}
+	if columnType == proto.ColumnType_JSON {
+		data, err := json.Marshal(qual.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.QualValue{Value: &proto.QualValue_JsonbValue{JsonbValue: string(data)}}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value %v for column %s of type %s", qual.Value, qual.Column, columnType)
+}
+
+// columnValue converts a column of a result row to its JSON form
+func columnValue(column *proto.Column) (any, error) {
+	var value any
+	switch v := column.Value.(type) {
+	case *proto.Column_NullValue:
+		return nil, nil
+	case *proto.Column_StringValue:
+		value = v.StringValue
+	case *proto.Column_IntValue:
+		value = v.IntValue
+	case *proto.Column_DoubleValue:
+		value = v.DoubleValue
+	case *proto.Column_BoolValue:
+		value = v.BoolValue
+	case *proto.Column_TimestampValue:
+		value = v.TimestampValue.AsTime().UTC().Format(time.RFC3339)
+	case *proto.Column_IpAddrValue:
+		value = v.IpAddrValue
+	case *proto.Column_CidrRangeValue:
+		value = v.CidrRangeValue
+	case *proto.Column_JsonValue:
+		value = json.RawMessage(v.JsonValue)
+	default:
+		return nil, fmt.Errorf("unsupported column value %T", v)
+	}
+
+	// Round trip through JSON, so values compare equal to the ones decoded from fixtures
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	err = json.Unmarshal(data, &normalized)
+	return normalized, err
+}
+
+// rowCollector is the result stream of an in process query. The plugin only uses the context
+// of the stream and sends rows on it, the other methods of the gRPC stream are not implemented.
+type rowCollector struct {
+	proto.WrapperPlugin_ExecuteServer
+
+	ctx  context.Context
+	mu   sync.Mutex
+	rows []*proto.Row
+}
+
+func (c *rowCollector) Context() context.Context {
+	return c.ctx
+}
+
+func (c *rowCollector) Send(response *proto.ExecuteResponse) error {
+	if response == nil || response.Row == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rows = append(c.rows, response.Row)
+	return nil
+}
@@ -0,0 +1,96 @@
+package alicloud
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// serviceRateLimiter is the request rate limit of a service, shared by the clients of all regions of a connection
+type serviceRateLimiter struct {
+	limit   float64
+	limiter *rate.Limiter
+}
+
+var (
+	serviceRateLimitersMu sync.Mutex
+	// serviceRateLimiters is keyed by connection and service
+	serviceRateLimiters = map[string]*serviceRateLimiter{}
+)
+
+// getServiceRateLimiter returns the rate limiter of a service set by the "rate_limits" argument
+// of the connection config, or nil if the service is not rate limited.
+// The limiter is replaced when the connection config changes the limit of the service.
+func getServiceRateLimiter(connection *plugin.Connection, service string) (*rate.Limiter, error) {
+	alicloudConfig := GetConfig(connection)
+
+	if err := validateServiceNames("rate_limits", alicloudConfig.RateLimits); err != nil {
+		return nil, err
+	}
+
+	limit, ok := alicloudConfig.RateLimits[service]
+	if !ok {
+		return nil, nil
+	}
+	if limit <= 0 || math.IsInf(limit, 0) || math.IsNaN(limit) {
+		return nil, fmt.Errorf("connection config has invalid value for \"rate_limits\" of %s: %v, it must be greater than 0", service, limit)
+	}
+
+	connectionName := ""
+	if connection != nil {
+		connectionName = connection.Name
+	}
+	key := connectionName + "/" + service
+
+	serviceRateLimitersMu.Lock()
+	defer serviceRateLimitersMu.Unlock()
+
+	if existing, ok := serviceRateLimiters[key]; ok && existing.limit == limit {
+		return existing.limiter, nil
+	}
+
+	// Allow a burst of one second of requests, so a limit below 1 still lets single requests through
+	limiter := rate.NewLimiter(rate.Limit(limit), max(1, int(math.Ceil(limit))))
+	serviceRateLimiters[key] = &serviceRateLimiter{limit: limit, limiter: limiter}
+	return limiter, nil
+}
+
+// rateLimitedTransport waits for the rate limiter before sending each request, including retries
+type rateLimitedTransport struct {
+	limiter *rate.Limiter
+	base    http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// rateLimitedOpenAPIClient is the HTTP client of a rate limited OpenAPI client.
+// Like the default OpenAPI HTTP client, it keeps the transport it is first called with,
+// which the OpenAPI client builds from the timeout and proxy settings of its config.
+type rateLimitedOpenAPIClient struct {
+	limiter *rate.Limiter
+	timeout time.Duration
+
+	once   sync.Once
+	client *http.Client
+}
+
+func (c *rateLimitedOpenAPIClient) Call(request *http.Request, transport *http.Transport) (*http.Response, error) {
+	c.once.Do(func() {
+		c.client = &http.Client{
+			Transport: &rateLimitedTransport{limiter: c.limiter, base: transport},
+			Timeout:   c.timeout,
+		}
+	})
+	return c.client.Do(request)
+}
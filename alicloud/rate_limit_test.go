@@ -0,0 +1,129 @@
+package alicloud
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func rateLimitTestConnection(name string, limits map[string]float64) *plugin.Connection {
+	return &plugin.Connection{Name: name, Config: alicloudConfig{RateLimits: limits}}
+}
+
+func TestServiceRateLimiters(t *testing.T) {
+	first := rateLimitTestConnection("alicloud_rate_limit_test_first", map[string]float64{"ecs": 5, "oss": 0.5})
+	second := rateLimitTestConnection("alicloud_rate_limit_test_second", map[string]float64{"ecs": 5})
+
+	ecs, err := getServiceRateLimiter(first, "ecs")
+	if err != nil || ecs == nil {
+		t.Fatalf("expected a limiter for ecs, got %v, %v", ecs, err)
+	}
+
+	// The regions of a connection share the limiter of a service
+	if again, _ := getServiceRateLimiter(first, "ecs"); again != ecs {
+		t.Error("expected the same limiter for the same connection and service")
+	}
+	if other, _ := getServiceRateLimiter(first, "oss"); other == ecs {
+		t.Error("expected a limiter per service")
+	}
+	if other, _ := getServiceRateLimiter(second, "ecs"); other == ecs {
+		t.Error("expected a limiter per connection")
+	}
+
+	// A limit below 1 still lets single requests through
+	if oss, _ := getServiceRateLimiter(first, "oss"); oss.Burst() != 1 || oss.Limit() != 0.5 {
+		t.Errorf("unexpected oss limiter: limit %v, burst %d", oss.Limit(), oss.Burst())
+	}
+
+	if none, err := getServiceRateLimiter(first, "ram"); none != nil || err != nil {
+		t.Errorf("expected no limiter for a service without a limit, got %v, %v", none, err)
+	}
+
+	// The limiter is replaced when the connection config changes the limit
+	changed := rateLimitTestConnection("alicloud_rate_limit_test_first", map[string]float64{"ecs": 10})
+	replaced, err := getServiceRateLimiter(changed, "ecs")
+	if err != nil || replaced == ecs || replaced.Limit() != 10 {
+		t.Errorf("expected a new limiter for the changed limit, got %v, %v", replaced, err)
+	}
+}
+
+func TestServiceRateLimitersRejectInvalidLimits(t *testing.T) {
+	cases := map[string]struct {
+		limits map[string]float64
+		err    string
+	}{
+		"zero":            {map[string]float64{"ecs": 0}, `invalid value for "rate_limits" of ecs: 0`},
+		"negative":        {map[string]float64{"ecs": -1}, `invalid value for "rate_limits" of ecs: -1`},
+		"unknown service": {map[string]float64{"ecss": 1}, `unknown services in "rate_limits": ecss`},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := getServiceRateLimiter(rateLimitTestConnection("alicloud_rate_limit_test_invalid", tc.limits), "ecs")
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+// countingTransport returns a transport which does not keep connections alive, and counts the connections it dials
+func countingTransport(dials *atomic.Int32) *http.Transport {
+	return &http.Transport{
+		DisableKeepAlives: true,
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials.Add(1)
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}
+}
+
+func TestRateLimitedOpenAPIClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	// A single request is allowed, and no more are until long after the test
+	client := &rateLimitedOpenAPIClient{limiter: rate.NewLimiter(rate.Every(time.Hour), 1), timeout: time.Second}
+
+	var firstDials, secondDials atomic.Int32
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Call(request, countingTransport(&firstDials))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	// The next request waits for the limiter, which gives up as it would wait past the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.Call(request.WithContext(ctx), countingTransport(&secondDials)); err == nil {
+		t.Error("expected the rate limited request to fail at its deadline")
+	}
+
+	if firstDials.Load() != 1 || secondDials.Load() != 0 {
+		t.Errorf("expected one request through the first transport only, got %d and %d", firstDials.Load(), secondDials.Load())
+	}
+
+	// The client keeps the transport it is first called with
+	client.limiter.SetLimit(rate.Inf)
+	resp, err = client.Call(request, countingTransport(&secondDials))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if firstDials.Load() != 2 || secondDials.Load() != 0 {
+		t.Errorf("expected the first transport to be kept, got %d and %d dials", firstDials.Load(), secondDials.Load())
+	}
+}
@@ -0,0 +1,95 @@
+package alicloud
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+
+	rds "github.com/alibabacloud-go/rds-20140815/v16/client"
+	"github.com/alibabacloud-go/tea/tea"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Sensitive columns hold secrets. Unless "redact_sensitive_columns" is set to false in the connection config,
+// the hydrate functions which return them replace their values before the rows are streamed or logged:
+//   - alicloud_cas_certificate.key: the SHA-256 fingerprint of the private key
+//   - alicloud_fc_function.environment_variables: the names of the variables, with NULL values
+//   - alicloud_rds_instance.parameters: NULL values of the parameters named like secrets, see sensitiveRDSParameterName
+//   - alicloud_sae_application.envs: the variables, with NULL values
+//   - alicloud_sae_application.oss_ak_secret: NULL
+
+// sensitiveRDSParameterName matches the names of RDS parameters which hold secrets, e.g. passwords or keys.
+// Password policies, e.g. validate_password_length or default_password_lifetime, are settings and are kept.
+var sensitiveRDSParameterName = regexp.MustCompile(`(?i)(^password$|_password$|passphrase|secret|credential|_token$|private_key|access_key)`)
+
+// shouldRedactSensitiveColumns returns true unless the connection opts out of redaction
+func shouldRedactSensitiveColumns(connection *plugin.Connection) bool {
+	redact := GetConfig(connection).RedactSensitiveColumns
+	return redact == nil || *redact
+}
+
+// fingerprintSecret returns the SHA-256 fingerprint of a secret, so that secrets can be compared without being disclosed
+func fingerprintSecret(secret *string) *string {
+	if secret == nil || *secret == "" {
+		return secret
+	}
+	sum := sha256.Sum256([]byte(*secret))
+	return tea.String("sha256:" + hex.EncodeToString(sum[:]))
+}
+
+// redactEnvironmentVariables keeps the names of environment variables and drops their values
+func redactEnvironmentVariables(variables map[string]*string) map[string]*string {
+	if variables == nil {
+		return nil
+	}
+	redacted := make(map[string]*string, len(variables))
+	for name := range variables {
+		redacted[name] = nil
+	}
+	return redacted
+}
+
+// redactSAEEnvs drops the values of SAE environment variables, a JSON array of {"name": "...", "value": "..."} objects.
+// References to config maps or secrets are kept. Content in any other format is dropped entirely.
+func redactSAEEnvs(envs *string) *string {
+	if envs == nil || *envs == "" {
+		return envs
+	}
+
+	var variables []map[string]interface{}
+	if err := json.Unmarshal([]byte(*envs), &variables); err != nil {
+		return nil
+	}
+	for _, variable := range variables {
+		if _, ok := variable["value"]; ok {
+			variable["value"] = nil
+		}
+	}
+
+	redacted, err := json.Marshal(variables)
+	if err != nil {
+		return nil
+	}
+	return tea.String(string(redacted))
+}
+
+// redactRDSParameters drops the values of the configured and running RDS parameters named like secrets
+func redactRDSParameters(parameters *rds.DescribeParametersResponseBody) {
+	if parameters.ConfigParameters != nil {
+		for _, parameter := range parameters.ConfigParameters.DBInstanceParameter {
+			if sensitiveRDSParameterName.MatchString(tea.StringValue(parameter.ParameterName)) {
+				parameter.ParameterValue = nil
+			}
+		}
+	}
+	if parameters.RunningParameters != nil {
+		for _, parameter := range parameters.RunningParameters.DBInstanceParameter {
+			if sensitiveRDSParameterName.MatchString(tea.StringValue(parameter.ParameterName)) {
+				parameter.ParameterValue = nil
+				parameter.ParameterDefaultValue = nil
+			}
+		}
+	}
+}
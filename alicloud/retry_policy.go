@@ -0,0 +1,179 @@
+package alicloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	sls "github.com/aliyun/aliyun-log-go-sdk"
+)
+
+// throttlingErrorCodes are the error codes of the OpenAPI, OSS and SLS services for throttled API calls
+// and temporarily unavailable services, which are always retried
+var throttlingErrorCodes = []string{
+	"Throttling",
+	"Throttling.Api",
+	"Throttling.User",
+	"Throttling.Tenant",
+	"Throttling.ConcurrentLimitExceeded",
+	"ServiceUnavailable",
+	"ServerBusy",
+	"ReadQuotaExceed",
+	"ShardReadQuotaExceed",
+}
+
+// serverErrorCodes are the error codes of internal server errors, which are retried with "auto_retry" only
+var serverErrorCodes = []string{
+	"InternalError",
+}
+
+// retryBaseDelay is the upper bound of the delay before the first retry, doubled for every further retry
+var retryBaseDelay = time.Second
+
+// retryDelay returns the delay before a retry, starting at 1 for the first retry.
+// The delay grows exponentially from retryBaseDelay up to maxClientRetryDelay, with equal jitter
+// so that the queries of a connection which are throttled together do not retry together.
+func retryDelay(retry int) time.Duration {
+	delay := maxClientRetryDelay
+	if retry < 1 {
+		retry = 1
+	}
+	if retry < 32 {
+		if d := retryBaseDelay << (retry - 1); d > 0 && d < delay {
+			delay = d
+		}
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+//// OPENAPI
+
+// openAPIBackoff is the retry policy of the OpenAPI clients. The OpenAPI client sleeps for the
+// backoff delay in seconds (dara.Sleep), so the delay is rounded up to the next second.
+type openAPIBackoff struct{}
+
+func (openAPIBackoff) GetDelayTime(ctx *dara.RetryPolicyContext) int {
+	return int(math.Ceil(retryDelay(ctx.RetriesAttempted).Seconds()))
+}
+
+//// OSS
+
+// ossBackoff is the retry policy of the OSS client. The client passes the number of the attempt
+// being made, starting at 2 for the first retry.
+type ossBackoff struct{}
+
+func (ossBackoff) BackoffDelay(attempt int, _ error) (time.Duration, error) {
+	return retryDelay(attempt - 1), nil
+}
+
+// ossErrorCodeRetryable retries the OSS errors with one of its error codes
+type ossErrorCodeRetryable struct {
+	codes []string
+}
+
+func (r ossErrorCodeRetryable) IsErrorRetryable(err error) bool {
+	var serviceErr interface{ ErrorCode() string }
+	return errors.As(err, &serviceErr) && slices.Contains(r.codes, serviceErr.ErrorCode())
+}
+
+//// SLS
+
+func init() {
+	// The SLS clients are retried by slsRetryTransport only, see applyToSLSClient. It retries the
+	// connection errors and 5xx responses the SDK would otherwise retry, with or without "auto_retry".
+	// The plugin runs in its own process, so this only applies to its SLS clients.
+	sls.RetryOnServerErrorEnabled = false
+}
+
+// slsRetryTransport retries the SLS requests which fail with a connection error, a 5xx response
+// or a throttling error code, e.g. 403 ReadQuotaExceed, up to attempts times.
+// The SLS client retries connection errors on its own until its retry timeout, and cannot be told
+// not to, so the transport returns them as a 503 response with the slsRequestErrorCode error code.
+type slsRetryTransport struct {
+	base     http.RoundTripper
+	attempts int
+}
+
+// maxSLSErrorBodySize bounds the error response bodies read to find their error code
+const maxSLSErrorBodySize = 64 * 1024
+
+// slsRequestErrorCode is the error code of the requests which got no response from SLS
+const slsRequestErrorCode = "RequestError"
+
+func (t *slsRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	current := req
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(current)
+		if err == nil && resp.StatusCode < http.StatusBadRequest {
+			return resp, nil
+		}
+
+		// A request body can only be sent again if it can be rewound
+		last := attempt >= t.attempts || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil)
+		if err != nil {
+			if last {
+				return newSLSRequestErrorResponse(req, err), nil
+			}
+		} else {
+			data, readErr := io.ReadAll(io.LimitReader(resp.Body, maxSLSErrorBodySize))
+			resp.Body.Close()
+			if readErr != nil {
+				return newSLSRequestErrorResponse(req, readErr), nil
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(data))
+
+			var body struct {
+				ErrorCode string `json:"errorCode"`
+			}
+			retryable := resp.StatusCode >= http.StatusInternalServerError || (json.Unmarshal(data, &body) == nil && slices.Contains(throttlingErrorCodes, body.ErrorCode))
+			if last || !retryable {
+				return resp, nil
+			}
+		}
+
+		select {
+		case <-req.Context().Done():
+			if err != nil {
+				return newSLSRequestErrorResponse(req, err), nil
+			}
+			return resp, nil
+		case <-time.After(retryDelay(attempt)):
+		}
+
+		current = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if current.Body, err = req.GetBody(); err != nil {
+				return newSLSRequestErrorResponse(req, err), nil
+			}
+		}
+	}
+}
+
+// newSLSRequestErrorResponse returns the error of a request which got no response from SLS as an SLS error
+// response, which the SLS client returns as is instead of retrying it
+func newSLSRequestErrorResponse(req *http.Request, err error) *http.Response {
+	data, _ := json.Marshal(map[string]string{
+		"errorCode":    slsRequestErrorCode,
+		"errorMessage": err.Error(),
+	})
+	return &http.Response{
+		Status:        "503 Service Unavailable",
+		StatusCode:    http.StatusServiceUnavailable,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,177 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc is a transport which answers requests with a function
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// slsResponse returns an SLS response of the status, with an error document if the error code is set
+func slsResponse(status int, errorCode string) *http.Response {
+	body := "{}"
+	if errorCode != "" {
+		body = fmt.Sprintf(`{"errorCode": %q, "errorMessage": "stub"}`, errorCode)
+	}
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestSLSRetryTransport(t *testing.T) {
+	errConnection := errors.New("connection refused")
+
+	cases := []struct {
+		name     string
+		attempts int
+		// responses are the results of the attempts, an error code of "-" fails to connect
+		responses []string
+		calls     int
+		status    int
+		errorCode string
+	}{
+		{
+			name:      "retries a retryable error code",
+			attempts:  3,
+			responses: []string{"403 ReadQuotaExceed", "200"},
+			calls:     2,
+			status:    http.StatusOK,
+		},
+		{
+			name:      "retries a 5xx response",
+			attempts:  3,
+			responses: []string{"502 -", "200"},
+			calls:     2,
+			status:    http.StatusOK,
+		},
+		{
+			name:      "retries a connection error",
+			attempts:  3,
+			responses: []string{"0 -", "200"},
+			calls:     2,
+			status:    http.StatusOK,
+		},
+		{
+			name:      "returns other errors as is",
+			attempts:  3,
+			responses: []string{"403 Unauthorized"},
+			calls:     1,
+			status:    http.StatusForbidden,
+			errorCode: "Unauthorized",
+		},
+		{
+			name:      "returns the last response once the attempts are used up",
+			attempts:  2,
+			responses: []string{"403 ReadQuotaExceed", "403 ShardReadQuotaExceed"},
+			calls:     2,
+			status:    http.StatusForbidden,
+			errorCode: "ShardReadQuotaExceed",
+		},
+		{
+			name:      "returns a connection error as a response the SDK does not retry",
+			attempts:  2,
+			responses: []string{"0 -", "0 -"},
+			calls:     2,
+			status:    http.StatusServiceUnavailable,
+			errorCode: slsRequestErrorCode,
+		},
+		{
+			name:      "makes a single attempt when max_retry_time is 1",
+			attempts:  1,
+			responses: []string{"0 -"},
+			calls:     1,
+			status:    http.StatusServiceUnavailable,
+			errorCode: slsRequestErrorCode,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var bodies []string
+			transport := &slsRetryTransport{
+				attempts: tc.attempts,
+				base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(req.Body)
+					bodies = append(bodies, string(body))
+					if len(bodies) > len(tc.responses) {
+						t.Fatalf("unexpected attempt %d", len(bodies))
+					}
+
+					var status int
+					var errorCode string
+					fmt.Sscanf(tc.responses[len(bodies)-1], "%d %s", &status, &errorCode)
+					if status == 0 {
+						return nil, errConnection
+					}
+					return slsResponse(status, strings.TrimPrefix(errorCode, "-")), nil
+				}),
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "http://audit.127.0.0.1/logstores", strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(bodies) != tc.calls {
+				t.Errorf("expected %d attempts, got %d", tc.calls, len(bodies))
+			}
+			for i, body := range bodies {
+				if body != "payload" {
+					t.Errorf("attempt %d sent body %q", i+1, body)
+				}
+			}
+			if resp.StatusCode != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+
+			var body struct {
+				ErrorCode    string `json:"errorCode"`
+				ErrorMessage string `json:"errorMessage"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to read the response body: %v", err)
+			}
+			if body.ErrorCode != tc.errorCode {
+				t.Errorf("expected error code %q, got %q", tc.errorCode, body.ErrorCode)
+			}
+			if tc.errorCode == slsRequestErrorCode && !strings.Contains(body.ErrorMessage, errConnection.Error()) {
+				t.Errorf("expected the connection error in the message, got %q", body.ErrorMessage)
+			}
+		})
+	}
+}
+
+func TestSLSRetryTransportDoesNotResendUnrewindableBodies(t *testing.T) {
+	calls := 0
+	transport := &slsRetryTransport{
+		attempts: 3,
+		base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return slsResponse(http.StatusForbidden, "ReadQuotaExceed"), nil
+		}),
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "http://audit.127.0.0.1/logstores", io.NopCloser(strings.NewReader("payload")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected a single attempt returning 403, got %d attempts returning %d", calls, resp.StatusCode)
+	}
+}
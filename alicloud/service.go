@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"os"
 
+	openapiClient "github.com/alibabacloud-go/darabonba-openapi/v2/client"
 	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/utils"
 	"github.com/alibabacloud-go/tea/dara"
-	"github.com/alibabacloud-go/tea/tea"
 
 	actiontrail "github.com/alibabacloud-go/actiontrail-20200706/v3/client"
 	alidns "github.com/alibabacloud-go/alidns-20150109/v5/client"
@@ -31,7 +31,6 @@ import (
 	credentialProviders "github.com/aliyun/credentials-go/credentials/providers"
 
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
-	ossCred "github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
 	sls "github.com/aliyun/aliyun-log-go-sdk"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -39,16 +38,41 @@ import (
 
 // Credential configuration
 type CredentialConfig struct {
-	Cred          credential.Credential
+	Cred credential.Credential
+	// Provider is the provider behind Cred, used as the base credentials to assume roles
+	Provider      credentialProviders.CredentialsProvider
 	DefaultRegion string
+	// Source is the step of the credential resolution chain the credentials came from
+	Source string
 }
 
-// newOpenAPIConfig creates an OpenAPI config for the given region using the credential
-func newOpenAPIConfig(cred credential.Credential, region string) *openapi.Config {
-	return &openapi.Config{
+// newOpenAPIConfig creates an OpenAPI config for the given service and region using the credential
+// and the timeout, retry and endpoint settings of the connection
+func newOpenAPIConfig(d *plugin.QueryData, service string, cred credential.Credential, region string) (*openapi.Config, error) {
+	opts, err := getClientOptions(d.Connection, service)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := getServiceEndpoint(d.Connection, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &openapi.Config{
 		Credential: cred,
 		RegionId:   dara.String(region),
 	}
+	opts.applyToOpenAPIConfig(cfg)
+
+	if endpoint.Host != "" {
+		cfg.Endpoint = dara.String(endpoint.Host)
+	}
+	if endpoint.Protocol != "" {
+		cfg.Protocol = dara.String(endpoint.Protocol)
+	}
+
+	return cfg, nil
 }
 
 // AliDNSService returns the service connection for Alicloud DNS service
@@ -56,21 +80,28 @@ func AliDNSService(ctx context.Context, d *plugin.QueryData) (*alidns.Client, er
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed AliDNSService")
 	}
 
-	serviceCacheKey := fmt.Sprintf("alidns-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("alidns-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*alidns.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "alidns", cfg.Cred, region)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := alidns.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	svc, err := alidns.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -84,20 +115,27 @@ func AutoscalingService(ctx context.Context, d *plugin.QueryData) (*ess.Client,
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed AutoscalingService")
 	}
-	serviceCacheKey := fmt.Sprintf("ess-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("ess-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*ess.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := ess.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	clientCfg, err := newOpenAPIConfig(d, "ess", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := ess.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -109,20 +147,27 @@ func AutoscalingService(ctx context.Context, d *plugin.QueryData) (*ess.Client,
 // CasService returns the service connection for Alicloud SSL service
 func CasService(ctx context.Context, d *plugin.QueryData, region string) (*cas.Client, error) {
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed CasService")
 	}
-	serviceCacheKey := fmt.Sprintf("cas-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("cas-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*cas.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "cas", cfg.Cred, region)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := cas.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	svc, err := cas.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -138,18 +183,22 @@ func CmsService(ctx context.Context, d *plugin.QueryData) (*cms.Client, error) {
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed CmsService")
 	}
-	serviceCacheKey := fmt.Sprintf("cms-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("cms-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*cms.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := cms.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	clientCfg, err := newOpenAPIConfig(d, "cms", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := cms.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -163,20 +212,27 @@ func ECSService(ctx context.Context, d *plugin.QueryData) (*ecs.Client, error) {
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed ECSService")
 	}
-	serviceCacheKey := fmt.Sprintf("ecs-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("ecs-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*ecs.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := ecs.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	clientCfg, err := newOpenAPIConfig(d, "ecs", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := ecs.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -188,20 +244,27 @@ func ECSService(ctx context.Context, d *plugin.QueryData) (*ecs.Client, error) {
 // ECSRegionService returns the service connection for Alicloud ECS Region service
 func ECSRegionService(ctx context.Context, d *plugin.QueryData, region string) (*ecs.Client, error) {
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed ECSRegionService")
 	}
-	serviceCacheKey := fmt.Sprintf("ecsregion-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("ecsregion-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*ecs.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := ecs.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	clientCfg, err := newOpenAPIConfig(d, "ecs", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := ecs.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -215,20 +278,27 @@ func KMSService(ctx context.Context, d *plugin.QueryData) (*kms.Client, error) {
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed KMSService")
 	}
-	serviceCacheKey := fmt.Sprintf("kms-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("kms-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*kms.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "kms", cfg.Cred, region)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := kms.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	svc, err := kms.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -241,18 +311,22 @@ func KMSService(ctx context.Context, d *plugin.QueryData) (*kms.Client, error) {
 func RAMService(ctx context.Context, d *plugin.QueryData) (*ram.Client, error) {
 	region := GetDefaultRegion(d.Connection)
 
-	serviceCacheKey := fmt.Sprintf("ram-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("ram-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*ram.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "ram", cfg.Cred, region)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := ram.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	svc, err := ram.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -264,18 +338,22 @@ func RAMService(ctx context.Context, d *plugin.QueryData) (*ram.Client, error) {
 func IMSService(ctx context.Context, d *plugin.QueryData) (*ims.Client, error) {
 	region := GetDefaultRegion(d.Connection)
 
-	serviceCacheKey := fmt.Sprintf("ims-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("ims-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*ims.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := ims.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	clientCfg, err := newOpenAPIConfig(d, "ims", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := ims.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -288,18 +366,22 @@ func IMSService(ctx context.Context, d *plugin.QueryData) (*ims.Client, error) {
 func SLBService(ctx context.Context, d *plugin.QueryData) (*slb.Client, error) {
 	region := GetDefaultRegion(d.Connection)
 
-	serviceCacheKey := fmt.Sprintf("slb-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("slb-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*slb.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "slb", cfg.Cred, region)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := slb.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	svc, err := slb.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -311,18 +393,22 @@ func SLBService(ctx context.Context, d *plugin.QueryData) (*slb.Client, error) {
 // StsService returns the service connection for Alicloud STS service
 func StsService(ctx context.Context, d *plugin.QueryData) (*sts.Client, error) {
 	region := GetDefaultRegion(d.Connection)
-	serviceCacheKey := fmt.Sprintf("sts-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("sts-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*sts.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := sts.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	clientCfg, err := newOpenAPIConfig(d, "sts", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := sts.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -337,6 +423,9 @@ func VpcService(ctx context.Context, d *plugin.QueryData) (*vpc.Client, error) {
 
 	// Fallback to the default region in connection config
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		region = GetDefaultRegion(d.Connection)
 	}
 
@@ -344,18 +433,22 @@ func VpcService(ctx context.Context, d *plugin.QueryData) (*vpc.Client, error) {
 		return nil, fmt.Errorf("region could not be determined for VpcService")
 	}
 
-	serviceCacheKey := fmt.Sprintf("vpc-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("vpc-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*vpc.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "vpc", cfg.Cred, region)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := vpc.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	svc, err := vpc.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -367,47 +460,41 @@ func VpcService(ctx context.Context, d *plugin.QueryData) (*vpc.Client, error) {
 // OssService returns the service connection for Alicloud OSS service
 func OssService(ctx context.Context, d *plugin.QueryData, region string) (*oss.Client, error) {
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be provided to initialize the OSS service")
 	}
 
-	serviceCacheKey := fmt.Sprintf("oss-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("oss-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*oss.Client), nil
 	}
 
-	endpoint := "oss-" + region + ".aliyuncs.com"
+	endpoint, err := getServiceEndpoint(d.Connection, "oss", region)
+	if err != nil {
+		return nil, err
+	}
 
 	ossCfg := oss.NewConfig()
-	ossCfg.WithEndpoint(endpoint)
+	ossCfg.WithEndpoint(endpoint.URL())
 	ossCfg.WithRegion(region)
 	ossCfg.WithProxyFromEnvironment(true)
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	opts, err := getClientOptions(d.Connection, "oss")
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve cached credentials: %v", err)
+		return nil, err
 	}
+	opts.applyToOSSConfig(ossCfg)
 
-	cfg := credCfg.(*CredentialConfig)
-
-	// Extract credentials from the v2 credential interface
-	accessKeyID, err := cfg.Cred.GetAccessKeyId()
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get access key id: %v", err)
-	}
-	accessKeySecret, err := cfg.Cred.GetAccessKeySecret()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access key secret: %v", err)
-	}
-	securityToken, err := cfg.Cred.GetSecurityToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get security token: %v", err)
+		return nil, fmt.Errorf("failed to retrieve cached credentials: %v", err)
 	}
 
-	ossCfg.CredentialsProvider = ossCred.NewStaticCredentialsProvider(
-		dara.StringValue(accessKeyID),
-		dara.StringValue(accessKeySecret),
-		dara.StringValue(securityToken),
-	)
+	// Credentials are read through the connection credential on every request,
+	// so temporary credentials are refreshed even though the client is cached
+	ossCfg.CredentialsProvider = newOSSCredentialsProvider(cfg.Cred)
 
 	svc := oss.NewClient(ossCfg)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
@@ -419,20 +506,27 @@ func ActionTrailService(ctx context.Context, d *plugin.QueryData) (*actiontrail.
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed ActionTrailService")
 	}
-	serviceCacheKey := fmt.Sprintf("actiontrail-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("actiontrail-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*actiontrail.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := actiontrail.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	clientCfg, err := newOpenAPIConfig(d, "actiontrail", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := actiontrail.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -446,20 +540,27 @@ func ContainerService(ctx context.Context, d *plugin.QueryData) (*cs.Client, err
 	region := GetDefaultRegion(d.Connection)
 
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed ContainerService")
 	}
-	serviceCacheKey := fmt.Sprintf("cs-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("cs-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*cs.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "cs", cfg.Cred, region)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := cs.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	svc, err := cs.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -471,21 +572,28 @@ func ContainerService(ctx context.Context, d *plugin.QueryData) (*cs.Client, err
 // SecurityCenterService returns the service connection for Alicloud Security Center service
 func SecurityCenterService(ctx context.Context, d *plugin.QueryData, region string) (*sas.Client, error) {
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed SecurityCenterService")
 	}
 
-	serviceCacheKey := fmt.Sprintf("sas-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("sas-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*sas.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "sas", cfg.Cred, region)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := sas.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	svc, err := sas.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -497,20 +605,57 @@ func SecurityCenterService(ctx context.Context, d *plugin.QueryData, region stri
 // RDSService returns the service connection for Alicloud RDS service
 func RDSService(ctx context.Context, d *plugin.QueryData, region string) (*rds.Client, error) {
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be passed RDSService")
 	}
-	serviceCacheKey := fmt.Sprintf("rds-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("rds-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*rds.Client), nil
 	}
 
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "rds", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := rds.NewClient(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
+	return svc, nil
+}
+
+// ResourceManagerService returns a generic OpenAPI client for the Alicloud Resource Manager service.
+// It always uses the connection credentials, since the resource directory is managed from the connection account.
+func ResourceManagerService(ctx context.Context, d *plugin.QueryData) (*openapiClient.Client, error) {
+	region := GetDefaultRegion(d.Connection)
+
+	serviceCacheKey := fmt.Sprintf("resourcemanager-%s", region)
+	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
+		return cachedData.(*openapiClient.Client), nil
+	}
+
 	credCfg, err := getCredentialSessionCached(ctx, d, nil)
 	if err != nil {
 		return nil, err
 	}
 	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := rds.NewClient(newOpenAPIConfig(cfg.Cred, region))
+	clientCfg, err := newOpenAPIConfig(d, "resourcemanager", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := openapiClient.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -522,41 +667,36 @@ func RDSService(ctx context.Context, d *plugin.QueryData, region string) (*rds.C
 // SLSService returns the client interface for Alicloud Log Service (SLS)
 func SLSService(ctx context.Context, d *plugin.QueryData, region string) (sls.ClientInterface, error) {
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("region must be provided to initialize the SLS service")
 	}
 
-	serviceCacheKey := fmt.Sprintf("sls-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("sls-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(sls.ClientInterface), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve cached credentials: %v", err)
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	// Extract credentials from the v2 credential interface
-	accessKeyId, err := cfg.Cred.GetAccessKeyId()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access key id: %v", err)
-	}
-	accessKeySecret, err := cfg.Cred.GetAccessKeySecret()
+	endpoint, err := getServiceEndpoint(d.Connection, "sls", region)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get access key secret: %v", err)
+		return nil, err
 	}
-	securityToken, err := cfg.Cred.GetSecurityToken()
+
+	// Credentials are read through the connection credential on every request,
+	// so temporary credentials are refreshed even though the client is cached
+	client := sls.CreateNormalInterfaceV2(endpoint.URL(), newSLSCredentialsProvider(cfg.Cred))
+
+	opts, err := getClientOptions(d.Connection, "sls")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get security token: %v", err)
+		return nil, err
 	}
-
-	staticProvider := sls.NewStaticCredentialsProvider(
-		dara.StringValue(accessKeyId),
-		dara.StringValue(accessKeySecret),
-		dara.StringValue(securityToken),
-	)
-	endpoint := region + ".log.aliyuncs.com"
-	client := sls.CreateNormalInterfaceV2(endpoint, staticProvider)
+	opts.applyToSLSClient(client)
 
 	d.ConnectionManager.Cache.Set(serviceCacheKey, client)
 	return client, nil
@@ -566,6 +706,9 @@ func FCService(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		region = GetDefaultRegion(d.Connection)
 	}
 
@@ -573,22 +716,22 @@ func FCService(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("region could not be determined for FcService")
 	}
 
-	serviceCacheKey := fmt.Sprintf("fc-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("fc-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*fc.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := fc.NewClient(&openapi.Config{
-		Credential: cfg.Cred,
-		RegionId:   &region,
-		Endpoint:   tea.String(fmt.Sprintf("fcv3.%s.aliyuncs.com", region)),
-	})
+	clientCfg, err := newOpenAPIConfig(d, "fc", cfg.Cred, region)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := fc.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -601,6 +744,9 @@ func SAEService(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	if region == "" {
+		if err := regionResolutionError(ctx, d); err != nil {
+			return nil, err
+		}
 		region = GetDefaultRegion(d.Connection)
 	}
 
@@ -608,21 +754,22 @@ func SAEService(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("region could not be determined for SAEService")
 	}
 
-	serviceCacheKey := fmt.Sprintf("sae-%s", region)
+	serviceCacheKey := accountCacheKey(d, fmt.Sprintf("sae-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(*sae.Client), nil
 	}
 
-	credCfg, err := getCredentialSessionCached(ctx, d, nil)
+	cfg, err := getAccountCredentialConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCfg, err := newOpenAPIConfig(d, "sae", cfg.Cred, region)
 	if err != nil {
 		return nil, err
 	}
-	cfg := credCfg.(*CredentialConfig)
 
-	svc, err := sae.NewClient(&openapi.Config{
-		Credential: cfg.Cred,
-		RegionId:   &region,
-	})
+	svc, err := sae.NewClient(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -642,12 +789,9 @@ func GetDefaultRegion(connection *plugin.Connection) string {
 		regions = alicloudConfig.Regions
 	}
 
-	if len(regions) > 0 {
-		region = regions[0]
-		if len(getInvalidRegions([]string{region})) > 0 {
-			panic("\n\nConnection config have invalid region: " + region + ". Edit your connection configuration file and then restart Steampipe")
-		}
-		return region
+	// A pattern like "*" or "cn-*" is resolved per query, so it can not be used as the default region
+	if len(regions) > 0 && !isRegionPattern(regions[0]) {
+		return regions[0]
 	}
 
 	if region == "" {
@@ -666,142 +810,3 @@ func GetDefaultRegion(connection *plugin.Connection) string {
 
 	return region
 }
-
-// https://github.com/aliyun/aliyun-cli/blob/master/README.md#supported-environment-variables
-func getEnvForProfile(_ context.Context, d *plugin.QueryData) (profile string) {
-	alicloudConfig := GetConfig(d.Connection)
-	if alicloudConfig.Profile != nil {
-		profile = *alicloudConfig.Profile
-	} else {
-		var ok bool
-		if profile, ok = os.LookupEnv("ALIBABACLOUD_PROFILE"); !ok {
-			if profile, ok = os.LookupEnv("ALIBABA_CLOUD_PROFILE"); !ok {
-				if profile, ok = os.LookupEnv("ALICLOUD_PROFILE"); !ok {
-					return ""
-				}
-			}
-		}
-	}
-	return profile
-}
-
-func getEnv(_ context.Context, d *plugin.QueryData) (secretKey string, accessKey string, sessionToken string, err error) {
-	alicloudConfig := GetConfig(d.Connection)
-
-	if alicloudConfig.AccessKey != nil {
-		accessKey = *alicloudConfig.AccessKey
-	} else {
-		var ok bool
-		if accessKey, ok = os.LookupEnv("ALIBABACLOUD_ACCESS_KEY_ID"); !ok {
-			if accessKey, ok = os.LookupEnv("ALICLOUD_ACCESS_KEY_ID"); !ok {
-				if accessKey, ok = os.LookupEnv("ALICLOUD_ACCESS_KEY"); !ok {
-					panic("\n'access_key' or 'profile' must be set in the connection configuration. Edit your connection configuration file and then restart Steampipe.")
-				}
-			}
-		}
-	}
-
-	if alicloudConfig.SecretKey != nil {
-		secretKey = *alicloudConfig.SecretKey
-	} else {
-		var ok bool
-		if secretKey, ok = os.LookupEnv("ALIBABACLOUD_ACCESS_KEY_SECRET"); !ok {
-			if secretKey, ok = os.LookupEnv("ALICLOUD_ACCESS_KEY_SECRET"); !ok {
-				if secretKey, ok = os.LookupEnv("ALICLOUD_SECRET_KEY"); !ok {
-					panic("\n'secret_key' or 'profile' must be set in the connection configuration. Edit your connection configuration file and then restart Steampipe.")
-				}
-			}
-		}
-	}
-
-	if alicloudConfig.SessionToken != nil {
-		sessionToken = *alicloudConfig.SessionToken
-	} else {
-		var ok bool
-		if sessionToken, ok = os.LookupEnv("ALIBABACLOUD_ACCESS_KEY_SECRET"); !ok {
-			if sessionToken, ok = os.LookupEnv("ALICLOUD_ACCESS_KEY_SECRET"); !ok {
-				sessionToken, _ = os.LookupEnv("ALICLOUD_SECRET_KEY")
-			}
-		}
-	}
-
-	return accessKey, secretKey, sessionToken, nil
-}
-
-// Get credential from the profile configuration for Alicloud CLI
-func getProfileConfigurations(_ context.Context, d *plugin.QueryData) (*CredentialConfig, error) {
-	alicloudConfig := GetConfig(d.Connection)
-	profile := alicloudConfig.Profile
-
-	cfg, err := getCredentialConfigByProfile(*profile, d)
-	if err != nil {
-		return nil, err
-	}
-
-	return cfg, nil
-}
-
-func getCredentialConfigByProfile(profile string, d *plugin.QueryData) (*CredentialConfig, error) {
-	defaultRegion := GetDefaultRegion(d.Connection)
-
-	provider, err := credentialProviders.NewCLIProfileCredentialsProviderBuilder().
-		WithProfileName(profile).
-		Build()
-	if err != nil {
-		return nil, err
-	}
-
-	cred := credential.FromCredentialsProvider("cli_profile", provider)
-
-	return &CredentialConfig{cred, defaultRegion}, nil
-}
-
-var getCredentialSessionCached = plugin.HydrateFunc(getCredentialSessionUncached).Memoize()
-
-func getCredentialSessionUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	config := GetConfig(d.Connection)
-	defaultRegion := GetDefaultRegion(d.Connection)
-
-	// Profile based client
-	if config.Profile != nil {
-		return getProfileConfigurations(ctx, d)
-	}
-
-	profileEnv := getEnvForProfile(ctx, d)
-	if profileEnv != "" {
-		return getCredentialConfigByProfile(profileEnv, d)
-	}
-
-	// Access key and Secret Key from environment variable
-	accessKey, secretKey, sessionToken, err := getEnv(ctx, d)
-	if err != nil {
-		return nil, err
-	}
-	if sessionToken != "" && accessKey != "" && secretKey != "" {
-		credConfig := &credential.Config{
-			Type:            dara.String("sts"),
-			AccessKeyId:     dara.String(accessKey),
-			AccessKeySecret: dara.String(secretKey),
-			SecurityToken:   dara.String(sessionToken),
-		}
-		cred, err := credential.NewCredential(credConfig)
-		if err != nil {
-			return nil, err
-		}
-		return &CredentialConfig{cred, defaultRegion}, nil
-	}
-	if accessKey != "" && secretKey != "" {
-		credConfig := &credential.Config{
-			Type:            dara.String("access_key"),
-			AccessKeyId:     dara.String(accessKey),
-			AccessKeySecret: dara.String(secretKey),
-		}
-		cred, err := credential.NewCredential(credConfig)
-		if err != nil {
-			return nil, err
-		}
-		return &CredentialConfig{cred, defaultRegion}, nil
-	}
-
-	return nil, nil
-}
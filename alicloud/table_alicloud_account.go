@@ -19,6 +19,7 @@ func tableAlicloudAccount(ctx context.Context) *plugin.Table {
 			Hydrate: listAccountAlias,
 			Tags:    map[string]string{"service": "ram", "action": "GetAccountAlias"},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "alias",
@@ -82,8 +83,7 @@ func getAccountAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateD
 	plugin.Logger(ctx).Trace("getAccountAkas")
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -30,6 +30,12 @@ func tableAlicloudUserCertificate(ctx context.Context) *plugin.Table {
 			Hydrate:    getUserCertificate,
 			Tags:       map[string]string{"service": "cas", "action": "GetUserCertificateDetail"},
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func:    getUserCertificateX509,
+				Depends: []plugin.HydrateFunc{getUserCertificate},
+			},
+		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: []*plugin.Column{
 			{
@@ -110,10 +116,72 @@ func tableAlicloudUserCertificate(ctx context.Context) *plugin.Table {
 			},
 			{
 				Name:        "key",
-				Description: "The private key of the certificate, in PEM format.",
+				Description: "The private key of the certificate, in PEM format. Unless redact_sensitive_columns is false in the connection config, the SHA-256 fingerprint of the key instead.",
 				Type:        proto.ColumnType_STRING,
 				Hydrate:     getUserCertificate,
 			},
+			{
+				Name:        "key_algorithm",
+				Description: "The public key algorithm of the certificate, e.g. RSA, ECDSA or Ed25519.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getUserCertificateX509,
+			},
+			{
+				Name:        "key_size",
+				Description: "The size of the public key of the certificate, in bits.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getUserCertificateX509,
+			},
+			{
+				Name:        "signature_algorithm",
+				Description: "The algorithm the issuer signed the certificate with, e.g. SHA256-RSA.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getUserCertificateX509,
+			},
+			{
+				Name:        "serial_number",
+				Description: "The serial number of the certificate, in hexadecimal.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getUserCertificateX509,
+			},
+			{
+				Name:        "chain_length",
+				Description: "The number of certificates in the certificate content, including the certificate itself.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getUserCertificateX509,
+			},
+			{
+				Name:        "intermediate_issuers",
+				Description: "The subjects of the intermediate CA certificates in the certificate content, in chain order.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getUserCertificateX509,
+			},
+			{
+				Name:        "ocsp_servers",
+				Description: "The URLs of the OCSP responders of the certificate.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getUserCertificateX509,
+			},
+			{
+				Name:        "crl_distribution_points",
+				Description: "The URLs of the certificate revocation lists of the certificate.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getUserCertificateX509,
+			},
+			{
+				Name:        "days_to_expiry",
+				Description: "The number of days until the certificate expires, negative if it has expired.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getUserCertificateX509,
+				Transform:   transform.FromField("DaysToExpiry"),
+			},
+			{
+				Name:        "weak_crypto",
+				Description: "Indicates whether the certificate uses weak cryptography: an RSA key shorter than 2048 bits, an ECDSA key shorter than 256 bits, a DSA key, or an MD5 or SHA-1 signature.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getUserCertificateX509,
+				Transform:   transform.FromField("WeakCrypto"),
+			},
 
 			// Steampipe standard columns
 			{
@@ -240,17 +308,41 @@ func getUserCertificate(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		return nil, err
 	}
 
+	if shouldRedactSensitiveColumns(d.Connection) {
+		response.Body.Key = fingerprintSecret(response.Body.Key)
+	}
+
 	return *response.Body, nil
 }
 
+func getUserCertificateX509(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getUserCertificateX509")
+
+	detail, ok := h.Item.(cas.GetUserCertificateDetailResponseBody)
+	if !ok {
+		detail, ok = h.HydrateResults["getUserCertificate"].(cas.GetUserCertificateDetailResponseBody)
+	}
+	if !ok || tea.StringValue(detail.Cert) == "" {
+		return nil, nil
+	}
+
+	details, err := parseCertificateChain(tea.StringValue(detail.Cert))
+	if err != nil {
+		// The parsed columns are empty, the certificate content is still returned in the cert column
+		plugin.Logger(ctx).Warn("alicloud_user_certificate.getUserCertificateX509", "certificate_id", tea.Int64Value(detail.Id), "parse_error", err)
+		return nil, nil
+	}
+
+	return details, nil
+}
+
 func getUserCertificateAka(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getUserCertificateAka")
 	region := d.EqualsQualString(matrixKeyRegion)
 	data := casCertificate(h.Item)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
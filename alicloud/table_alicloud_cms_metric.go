@@ -0,0 +1,287 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	cms "github.com/alibabacloud-go/cms-20190101/v10/client"
+	"github.com/alibabacloud-go/tea/tea"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Maximum page size of the DescribeMetricList API
+const cmsMetricPageSize = "1440"
+
+type cmsMetricDatapoint struct {
+	Namespace  string
+	MetricName string
+	Dimensions map[string]interface{}
+	// DimensionsFilter is the "dimensions_filter" qual, echoed so that the qual matches the rows
+	DimensionsFilter interface{}
+	Period           *int64
+	Statistics       []string
+	Values           map[string]interface{}
+	Datapoint        map[string]interface{}
+	Timestamp        string
+	Average          *float64
+	Maximum          *float64
+	Minimum          *float64
+	Sum              *float64
+	Value            *float64
+}
+
+//// TABLE DEFINITION
+
+func tableAlicloudCmsMetric(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "alicloud_cms_metric",
+		Description: "Alicloud Cloud Monitor metric datapoints for any namespace, metric and dimensions.",
+		List: &plugin.ListConfig{
+			Hydrate: listCmsMetrics,
+			Tags:    map[string]string{"service": "cms", "action": "DescribeMetricList"},
+			KeyColumns: append(plugin.KeyColumnSlice{
+				{Name: "namespace", Require: plugin.Required},
+				{Name: "metric_name", Require: plugin.Required},
+				{Name: "dimensions_filter", Require: plugin.Optional},
+				{Name: "period", Require: plugin.Optional},
+				{Name: "statistics", Require: plugin.Optional},
+			}, cmMetricKeyColumns()...),
+		},
+		GetMatrixItemFunc: BuildAccountList,
+		Columns: []*plugin.Column{
+			{
+				Name:        "namespace",
+				Description: "The namespace of the cloud service, e.g. acs_slb_dashboard.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "metric_name",
+				Description: "The name of the metric.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dimensions",
+				Description: "The dimensions of the datapoint, e.g. {\"instanceId\": \"lb-abc\", \"userId\": \"1234567890123456\"}.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "dimensions_filter",
+				Description: "The dimensions passed to the API to select datapoints, e.g. {\"instanceId\": \"lb-abc\"}, or an array of up to 50 dimension sets. Only set if given in the query.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "period",
+				Description: "The interval of the datapoints, in seconds. Valid values are 60, 300 and 900, the default depends on the metric.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "statistics",
+				Description: "The names of the statistics of the datapoint, e.g. [\"Average\", \"Maximum\"]. If set in the query, only these statistics are returned.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "timestamp",
+				Description: "The timestamp of the datapoint.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "average",
+				Description: "The average of the metric values of the datapoint.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "maximum",
+				Description: "The maximum metric value of the datapoint.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "minimum",
+				Description: "The minimum metric value of the datapoint.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "sum",
+				Description: "The sum of the metric values of the datapoint.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "value",
+				Description: "The value of the datapoint, for metrics which report a single value.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "values",
+				Description: "All statistics of the datapoint returned by the API, keyed by statistic name.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "datapoint",
+				Description: "The datapoint as returned by the API, including dimensions and all statistics.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Alicloud standard columns
+			{
+				Name:        "account_id",
+				Description: ColumnDescriptionAccount,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getCommonColumns,
+				Transform:   transform.FromField("AccountID"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listCmsMetrics(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	namespace := d.EqualsQualString("namespace")
+	metricName := d.EqualsQualString("metric_name")
+	if namespace == "" || metricName == "" {
+		return nil, nil
+	}
+
+	var dimensionsFilter interface{}
+	if d.EqualsQuals["dimensions_filter"] != nil {
+		if err := json.Unmarshal([]byte(d.EqualsQuals["dimensions_filter"].GetJsonbValue()), &dimensionsFilter); err != nil {
+			return nil, fmt.Errorf("invalid value for dimensions_filter, it must be a JSON object or an array of JSON objects: %v", err)
+		}
+	}
+
+	var statistics []string
+	if d.EqualsQuals["statistics"] != nil {
+		if err := json.Unmarshal([]byte(d.EqualsQuals["statistics"].GetJsonbValue()), &statistics); err != nil {
+			return nil, fmt.Errorf("invalid value for statistics, it must be a JSON array of statistic names: %v", err)
+		}
+	}
+
+	startTime, endTime := getCMTimeRange(d, getCMWindowForGranularity(""))
+	if !startTime.Before(endTime) {
+		return nil, nil
+	}
+
+	// Create service connection
+	client, err := CmsService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_cms_metric.listCmsMetrics", "connection_error", err)
+		return nil, err
+	}
+
+	request := &cms.DescribeMetricListRequest{
+		Namespace:  tea.String(namespace),
+		MetricName: tea.String(metricName),
+		StartTime:  tea.String(startTime.UTC().Format(cmMetricTimeFormat)),
+		EndTime:    tea.String(endTime.UTC().Format(cmMetricTimeFormat)),
+		Length:     tea.String(cmsMetricPageSize),
+	}
+	if dimensionsFilter != nil {
+		request.Dimensions = tea.String(d.EqualsQuals["dimensions_filter"].GetJsonbValue())
+	}
+	if d.EqualsQuals["period"] != nil {
+		request.Period = tea.String(strconv.FormatInt(d.EqualsQuals["period"].GetInt64Value(), 10))
+	}
+
+	for {
+		d.WaitForListRateLimit(ctx)
+		response, err := describeCMMetricList(ctx, client, request)
+		if err != nil {
+			logQueryError(ctx, d, h, "alicloud_cms_metric.listCmsMetrics", err, "request", request)
+			return nil, err
+		}
+
+		if response.Body.Datapoints == nil || *response.Body.Datapoints == "" {
+			return nil, nil
+		}
+
+		var datapoints []map[string]interface{}
+		if err := json.Unmarshal([]byte(*response.Body.Datapoints), &datapoints); err != nil {
+			return nil, err
+		}
+
+		var period *int64
+		if d.EqualsQuals["period"] != nil {
+			p := d.EqualsQuals["period"].GetInt64Value()
+			period = &p
+		} else if p, err := strconv.ParseInt(tea.StringValue(response.Body.Period), 10, 64); err == nil {
+			period = &p
+		}
+
+		for _, datapoint := range datapoints {
+			d.StreamListItem(ctx, newCmsMetricDatapoint(namespace, metricName, dimensionsFilter, statistics, period, datapoint))
+			// This will return zero if context has been cancelled (i.e due to manual cancellation) or
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if response.Body.NextToken == nil || *response.Body.NextToken == "" {
+			return nil, nil
+		}
+		request.NextToken = response.Body.NextToken
+	}
+}
+
+// newCmsMetricDatapoint splits a datapoint into its dimensions and statistics. Statistics are the numeric
+// fields, e.g. "Average", dimensions are the string fields, e.g. "instanceId" or "BucketName".
+// The dimensions filter and statistics set in the query are returned as they are, so that the quals match.
+func newCmsMetricDatapoint(namespace string, metricName string, dimensionsFilter interface{}, statistics []string, period *int64, datapoint map[string]interface{}) *cmsMetricDatapoint {
+	row := &cmsMetricDatapoint{
+		Namespace:        namespace,
+		MetricName:       metricName,
+		Dimensions:       map[string]interface{}{},
+		DimensionsFilter: dimensionsFilter,
+		Period:           period,
+		Statistics:       statistics,
+		Values:           map[string]interface{}{},
+		Datapoint:        datapoint,
+	}
+
+	var datapointStatistics []string
+	for key, value := range datapoint {
+		switch {
+		case key == "timestamp":
+			if timestamp, ok := value.(float64); ok {
+				row.Timestamp = formatTime(timestamp)
+			}
+		case isCmsMetricStatistic(value):
+			if statistics != nil && !slices.Contains(statistics, key) {
+				continue
+			}
+			row.Values[key] = value
+			datapointStatistics = append(datapointStatistics, key)
+		default:
+			row.Dimensions[key] = value
+		}
+	}
+
+	if row.Statistics == nil {
+		slices.Sort(datapointStatistics)
+		row.Statistics = datapointStatistics
+	}
+
+	for key, field := range map[string]**float64{
+		"Average": &row.Average,
+		"Maximum": &row.Maximum,
+		"Minimum": &row.Minimum,
+		"Sum":     &row.Sum,
+		"Value":   &row.Value,
+	} {
+		if value, ok := row.Values[key].(float64); ok {
+			*field = &value
+		}
+	}
+
+	return row
+}
+
+func isCmsMetricStatistic(value interface{}) bool {
+	_, ok := value.(float64)
+	return ok
+}
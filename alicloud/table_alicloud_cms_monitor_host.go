@@ -32,6 +32,7 @@ func tableAlicloudCmsMonitorHost(ctx context.Context) *plugin.Table {
 				Tags: map[string]string{"service": "cms", "action": "DescribeMonitoringAgentStatuses"},
 			},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "host_name",
@@ -240,8 +241,7 @@ func getCmsMonitoringHostAka(ctx context.Context, d *plugin.QueryData, h *plugin
 	data := h.Item.(cms.DescribeMonitoringAgentHostsResponseBodyHostsHost)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
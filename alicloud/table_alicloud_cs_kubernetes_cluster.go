@@ -34,9 +34,10 @@ func tableAlicloudCsKubernetesCluster(ctx context.Context) *plugin.Table {
 			},
 			{
 				Func: getCsKubernetesClusterNamespace,
-				Tags: map[string]string{"service": "cs", "action": "DescribeClusterNamespaces"},
+				Tags: map[string]string{"service": "cs", "action": "DescribeUserClusterNamespaces"},
 			},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "name",
@@ -503,8 +504,7 @@ func getCsKubernetesClusterARN(ctx context.Context, d *plugin.QueryData, h *plug
 	data := h.Item.(map[string]interface{})
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -22,12 +22,14 @@ func tableAlicloudCsKubernetesClusterNode(ctx context.Context) *plugin.Table {
 			Hydrate:       listCsKubernetesClusterNodes,
 			Tags:          map[string]string{"service": "cs", "action": "DescribeClusterNodes"},
 			ParentHydrate: listCsKubernetesClusters,
+			ParentTags:    map[string]string{"service": "cs", "action": "DescribeClustersV1"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AllColumns([]string{"cluster_id", "instance_id"}),
 			Hydrate:    getCsKubernetesClusterNode,
 			Tags:       map[string]string{"service": "cs", "action": "DescribeClusterNodes"},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "node_name",
@@ -247,8 +249,7 @@ func getCsKubernetesClusterNodeAka(ctx context.Context, d *plugin.QueryData, h *
 	nodeName := tea.StringValue(h.Item.(*NodeInfo).NodeName)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -479,8 +479,7 @@ func getEcsAutoscalingGroupAka(ctx context.Context, d *plugin.QueryData, h *plug
 	data := h.Item.(*ess.DescribeScalingGroupsResponseBodyScalingGroups)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
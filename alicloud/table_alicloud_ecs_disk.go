@@ -471,8 +471,7 @@ func getEcsDiskARN(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 	disk := h.Item.(ecs.DescribeDisksResponseBodyDisksDisk)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -18,7 +18,10 @@ func tableAlicloudEcsDiskMetricReadIops(_ context.Context) *plugin.Table {
 		Description: "Alicloud ECS Disk Cloud Monitor Metrics - Read IOPS",
 		List: &plugin.ListConfig{
 			ParentHydrate: listEcsInstance,
+			ParentTags:    map[string]string{"service": "ecs", "action": "DescribeInstances"},
 			Hydrate:       listEcsDisksMetricReadIops,
+			Tags:          map[string]string{"service": "cms", "action": "DescribeMetricList"},
+			KeyColumns:    cmMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: cmMetricColumns(
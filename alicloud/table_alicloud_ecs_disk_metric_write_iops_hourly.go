@@ -18,7 +18,10 @@ func tableAlicloudEcsDiskMetricWriteIopsHourly(_ context.Context) *plugin.Table
 		Description: "Alicloud ECS Disk Cloud Monitor Metrics - Write IOPS (Hourly)",
 		List: &plugin.ListConfig{
 			ParentHydrate: listEcsInstance,
+			ParentTags:    map[string]string{"service": "ecs", "action": "DescribeInstances"},
 			Hydrate:       listEcsDisksMetricWriteIopsHourly,
+			Tags:          map[string]string{"service": "cms", "action": "DescribeMetricList"},
+			KeyColumns:    cmMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: cmMetricColumns(
@@ -18,7 +18,10 @@ func tableAlicloudEcsInstanceMetricCpuUtilizationHourly(_ context.Context) *plug
 		Description: "Alicloud ECS Instance Cloud Monitor Metrics - CPU Utilization (Hourly)",
 		List: &plugin.ListConfig{
 			ParentHydrate: listEcsInstance,
+			ParentTags:    map[string]string{"service": "ecs", "action": "DescribeInstances"},
 			Hydrate:       listEcsInstanceMetricCpuUtilizationHourly,
+			Tags:          map[string]string{"service": "cms", "action": "DescribeMetricList"},
+			KeyColumns:    cmMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: cmMetricColumns(
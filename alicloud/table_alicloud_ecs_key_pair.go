@@ -179,8 +179,7 @@ func getEcsKeypairAka(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	// Get account details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -240,8 +240,7 @@ func getEcsLaunchTemplateAka(ctx context.Context, d *plugin.QueryData, h *plugin
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
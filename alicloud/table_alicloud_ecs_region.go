@@ -21,6 +21,7 @@ func tableAlicloudEcsRegion(ctx context.Context) *plugin.Table {
 			Hydrate: listEcsRegions,
 			Tags:    map[string]string{"service": "ecs", "action": "DescribeRegions"},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "region",
@@ -107,8 +108,7 @@ func getRegionAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 	data := h.Item.(ecs.DescribeRegionsResponseBodyRegionsRegion)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +160,11 @@ func listEcsSecurityGroups(ctx context.Context, d *plugin.QueryData, h *plugin.H
 		RegionId:   tea.String(d.EqualsQualString(matrixKeyRegion)),
 	}
 
+	// Only set by tables which list the rules of security groups, e.g. alicloud_ecs_security_group_rule
+	if id := d.EqualsQualString("security_group_id"); id != "" {
+		request.SecurityGroupIds = tea.String("[\"" + id + "\"]")
+	}
+
 	// If the request no of items is less than the paging max limit
 	// update limit to the requested no of results.
 	limit := d.QueryContext.Limit
@@ -262,8 +267,7 @@ func getEcsSecurityGroupARN(ctx context.Context, d *plugin.QueryData, h *plugin.
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,292 @@
+package alicloud
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	ecs "github.com/alibabacloud-go/ecs-20140526/v7/client"
+	"github.com/alibabacloud-go/tea/tea"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type ecsSecurityGroupRule struct {
+	ecs.DescribeSecurityGroupAttributeResponseBodyPermissionsPermission
+	SecurityGroupId   *string
+	SecurityGroupName *string
+	VpcId             *string
+	// Priority of the rule, the API returns it as a string
+	Priority        *int64
+	FromPort        *int64
+	ToPort          *int64
+	PortCount       *int64
+	IsPublicIngress bool
+}
+
+//// TABLE DEFINITION
+
+func tableAlicloudEcsSecurityGroupRule(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "alicloud_ecs_security_group_rule",
+		Description: "ECS Security Group Rule",
+		List: &plugin.ListConfig{
+			ParentHydrate: listEcsSecurityGroups,
+			ParentTags:    map[string]string{"service": "ecs", "action": "DescribeSecurityGroups"},
+			Hydrate:       listEcsSecurityGroupRules,
+			Tags:          map[string]string{"service": "ecs", "action": "DescribeSecurityGroupAttribute"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "security_group_id", Require: plugin.Optional},
+				{Name: "direction", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: BuildRegionList,
+		Columns: []*plugin.Column{
+			{
+				Name:        "security_group_rule_id",
+				Description: "The ID of the security group rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "security_group_id",
+				Description: "The ID of the security group the rule belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "security_group_name",
+				Description: "The name of the security group the rule belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC of the security group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direction",
+				Description: "The direction of the rule. Possible values are: ingress, and egress.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy",
+				Description: "The action of the rule. Possible values are: accept, and drop.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "priority",
+				Description: "The priority of the rule, from 1 to 100. A smaller value means a higher priority.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "ip_protocol",
+				Description: "The protocol of the rule. Possible values are: TCP, UDP, ICMP, ICMPv6, GRE, and ALL.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "port_range",
+				Description: "The destination port range of the rule as returned by the API, e.g. 22/22, or -1/-1 for all ports.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "from_port",
+				Description: "The first port of the destination port range. 1 if the rule applies to all ports, NULL for protocols without ports and for port lists.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("FromPort"),
+			},
+			{
+				Name:        "to_port",
+				Description: "The last port of the destination port range. 65535 if the rule applies to all ports, NULL for protocols without ports and for port lists.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ToPort"),
+			},
+			{
+				Name:        "port_count",
+				Description: "The number of ports in the destination port range, NULL for protocols without ports and for port lists.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "port_range_list_id",
+				Description: "The ID of the port list of the rule, instead of a port range.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_port_range",
+				Description: "The source port range of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_cidr_ip",
+				Description: "The source IPv4 CIDR block of an ingress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ipv6_source_cidr_ip",
+				Description: "The source IPv6 CIDR block of an ingress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_prefix_list_id",
+				Description: "The ID of the source prefix list of an ingress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_group_id",
+				Description: "The ID of the source security group of an ingress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_group_owner_account",
+				Description: "The account that owns the source security group of an ingress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dest_cidr_ip",
+				Description: "The destination IPv4 CIDR block of an egress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ipv6_dest_cidr_ip",
+				Description: "The destination IPv6 CIDR block of an egress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dest_prefix_list_id",
+				Description: "The ID of the destination prefix list of an egress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dest_group_id",
+				Description: "The ID of the destination security group of an egress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dest_group_owner_account",
+				Description: "The account that owns the destination security group of an egress rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "nic_type",
+				Description: "The network interface type of the rule. Possible values are: internet, and intranet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_public_ingress",
+				Description: "Indicates whether the rule accepts ingress traffic from any IPv4 or IPv6 address, i.e. 0.0.0.0/0 or ::/0.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("IsPublicIngress"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "create_time",
+				Description: "The time when the rule was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SecurityGroupRuleId"),
+			},
+
+			// alicloud standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getSecurityGroupRegion,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "account_id",
+				Description: ColumnDescriptionAccount,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getCommonColumns,
+				Transform:   transform.FromField("AccountID"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listEcsSecurityGroupRules(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	securityGroup := h.Item.(ecs.DescribeSecurityGroupsResponseBodySecurityGroupsSecurityGroup)
+
+	if id := d.EqualsQualString("security_group_id"); id != "" && id != tea.StringValue(securityGroup.SecurityGroupId) {
+		return nil, nil
+	}
+
+	// Create service connection
+	client, err := ECSService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ecs_security_group_rule.listEcsSecurityGroupRules", "connection_error", err)
+		return nil, err
+	}
+
+	request := &ecs.DescribeSecurityGroupAttributeRequest{
+		SecurityGroupId: securityGroup.SecurityGroupId,
+		RegionId:        tea.String(d.EqualsQualString(matrixKeyRegion)),
+		MaxResults:      tea.Int32(1000),
+	}
+	if direction := d.EqualsQualString("direction"); direction != "" {
+		request.Direction = tea.String(direction)
+	}
+
+	for {
+		d.WaitForListRateLimit(ctx)
+		response, err := client.DescribeSecurityGroupAttribute(request)
+		if err != nil {
+			logQueryError(ctx, d, h, "alicloud_ecs_security_group_rule.listEcsSecurityGroupRules", err, "request", request)
+			return nil, err
+		}
+
+		if response.Body.Permissions != nil {
+			for _, permission := range response.Body.Permissions.Permission {
+				d.StreamListItem(ctx, newEcsSecurityGroupRule(securityGroup, permission))
+				// This will return zero if context has been cancelled (i.e due to manual cancellation) or
+				// if there is a limit, it will return the number of rows required to reach this limit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+
+		if tea.StringValue(response.Body.NextToken) == "" {
+			return nil, nil
+		}
+		request.NextToken = response.Body.NextToken
+	}
+}
+
+// newEcsSecurityGroupRule parses the port range and exposure of a security group rule
+func newEcsSecurityGroupRule(securityGroup ecs.DescribeSecurityGroupsResponseBodySecurityGroupsSecurityGroup, permission *ecs.DescribeSecurityGroupAttributeResponseBodyPermissionsPermission) ecsSecurityGroupRule {
+	rule := ecsSecurityGroupRule{
+		DescribeSecurityGroupAttributeResponseBodyPermissionsPermission: *permission,
+		SecurityGroupId:   securityGroup.SecurityGroupId,
+		SecurityGroupName: securityGroup.SecurityGroupName,
+		VpcId:             securityGroup.VpcId,
+	}
+
+	if priority, err := strconv.ParseInt(tea.StringValue(permission.Priority), 10, 64); err == nil {
+		rule.Priority = &priority
+	}
+
+	if fromPort, toPort, ok := parsePortRange(tea.StringValue(permission.IpProtocol), tea.StringValue(permission.PortRange)); ok {
+		portCount := toPort - fromPort + 1
+		rule.FromPort, rule.ToPort, rule.PortCount = &fromPort, &toPort, &portCount
+	}
+
+	rule.IsPublicIngress = strings.EqualFold(tea.StringValue(permission.Direction), "ingress") &&
+		strings.EqualFold(tea.StringValue(permission.Policy), "accept") &&
+		(isAnyAddressCidr(tea.StringValue(permission.SourceCidrIp)) || isAnyAddressCidr(tea.StringValue(permission.Ipv6SourceCidrIp)))
+
+	return rule
+}
@@ -24,9 +24,11 @@ func tableAlicloudEcsZone(ctx context.Context) *plugin.Table {
 		Description: "Elastic Compute Zone",
 		List: &plugin.ListConfig{
 			ParentHydrate: listEcsRegions,
+			ParentTags:    map[string]string{"service": "ecs", "action": "DescribeRegions"},
 			Hydrate:       listEcsZones,
 			Tags:          map[string]string{"service": "ecs", "action": "DescribeZones"},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "zone_id",
@@ -151,8 +153,7 @@ func getZoneAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 	data := h.Item.(zoneInfo)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
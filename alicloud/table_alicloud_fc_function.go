@@ -33,6 +33,10 @@ func tableAlicloudFcFunction(ctx context.Context) *plugin.Table {
 				Func: getFunction,
 				Tags: map[string]string{"service": "fc", "action": "GetFunction"},
 			},
+			{
+				Func: getFunctionTags,
+				Tags: map[string]string{"service": "fc", "action": "ListTagResources"},
+			},
 		},
 		GetMatrixItemFunc: BuildFunctionComputeRegionList,
 		Columns: []*plugin.Column{
@@ -101,7 +105,7 @@ func tableAlicloudFcFunction(ctx context.Context) *plugin.Table {
 			},
 			{
 				Name:        "environment_variables",
-				Description: "The environment variables of the function. The configured environment variables can be accessed in the runtime environment.",
+				Description: "The environment variables of the function. The configured environment variables can be accessed in the runtime environment. Unless redact_sensitive_columns is false in the connection config, the values are NULL.",
 				Type:        proto.ColumnType_JSON,
 			},
 			{
@@ -350,6 +354,9 @@ func listFunctions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 			return nil, err
 		}
 		for _, fn := range response.Body.Functions {
+			if shouldRedactSensitiveColumns(d.Connection) {
+				fn.EnvironmentVariables = redactEnvironmentVariables(fn.EnvironmentVariables)
+			}
 			plugin.Logger(ctx).Warn("alicloud_fc_function.listFunctions", "item", fn)
 			d.StreamListItem(ctx, fn)
 			// This will return zero if context has been cancelled (i.e due to manual cancellation) or
@@ -392,6 +399,10 @@ func getFunction(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 		return nil, serverErr
 	}
 
+	if shouldRedactSensitiveColumns(d.Connection) {
+		response.Body.EnvironmentVariables = redactEnvironmentVariables(response.Body.EnvironmentVariables)
+	}
+
 	return response.Body, nil
 }
 
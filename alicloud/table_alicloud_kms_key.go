@@ -2,11 +2,9 @@ package alicloud
 
 import (
 	"context"
-	"time"
 
 	kms "github.com/alibabacloud-go/kms-20160120/v3/client"
 	"github.com/alibabacloud-go/tea/tea"
-	"github.com/sethvargo/go-retry"
 	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -42,6 +40,10 @@ func tableAlicloudKmsKey(ctx context.Context) *plugin.Table {
 				Func: getKeyTags,
 				Tags: map[string]string{"service": "kms", "action": "ListResourceTags"},
 			},
+			{
+				Func: getKeyAlias,
+				Tags: map[string]string{"service": "kms", "action": "ListAliasesByKeyId"},
+			},
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: []*plugin.Column{
@@ -303,24 +305,9 @@ func getKmsKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		KeyId: id,
 	}
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.DescribeKey(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				logQueryError(ctx, d, h, "alicloud_kms_key.getKmsKey", err, "request", request)
-				return err
-			}
-		}
-		return nil
-	})
+	response, err = client.DescribeKey(request)
 	if err != nil {
-		plugin.Logger(ctx).Error("alicloud_kms_key.getKmsKey", "query_retry_error", err, "request", request)
+		logQueryError(ctx, d, h, "alicloud_kms_key.getKmsKey", err, "request", request)
 		return nil, err
 	}
 
@@ -344,24 +331,9 @@ func getKeyAlias(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 	}
 	var response *kms.ListAliasesByKeyIdResponse
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.ListAliasesByKeyId(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				logQueryError(ctx, d, h, "alicloud_kms_key.getKeyAlias", err, "request", request)
-				return err
-			}
-		}
-		return nil
-	})
+	response, err = client.ListAliasesByKeyId(request)
 	if err != nil {
-		plugin.Logger(ctx).Error("alicloud_kms_key.getKeyAlias", "query_retry_error", err, "request", request)
+		logQueryError(ctx, d, h, "alicloud_kms_key.getKeyAlias", err, "request", request)
 		return nil, err
 	}
 
@@ -389,24 +361,9 @@ func getKeyTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		KeyId: data.KeyId,
 	}
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.ListResourceTags(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				logQueryError(ctx, d, h, "alicloud_kms_key.getKeyTags", err, "request", request)
-				return err
-			}
-		}
-		return nil
-	})
+	response, err = client.ListResourceTags(request)
 	if err != nil {
-		plugin.Logger(ctx).Error("alicloud_kms_key.getKeyTags", "query_retry_error", err, "request", request)
+		logQueryError(ctx, d, h, "alicloud_kms_key.getKeyTags", err, "request", request)
 		return nil, err
 	}
 
@@ -3,9 +3,6 @@ package alicloud
 import (
 	"context"
 	"strings"
-	"time"
-
-	"github.com/sethvargo/go-retry"
 
 	kms "github.com/alibabacloud-go/kms-20160120/v3/client"
 	"github.com/alibabacloud-go/tea/tea"
@@ -242,24 +239,9 @@ func getKmsSecret(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 		FetchTags:  tea.String("true"),
 	}
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.DescribeSecret(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				logQueryError(ctx, d, h, "alicloud_kms_key.getKmsSecret", err, "request", request)
-				return err
-			}
-		}
-		return nil
-	})
+	response, err = client.DescribeSecret(request)
 	if err != nil {
-		plugin.Logger(ctx).Error("alicloud_kms_secret.getKmsSecret", "query_retry_error", err, "request", request)
+		logQueryError(ctx, d, h, "alicloud_kms_key.getKmsSecret", err, "request", request)
 		return nil, err
 	}
 
@@ -283,24 +265,9 @@ func listKmsSecretVersionIds(ctx context.Context, d *plugin.QueryData, h *plugin
 		IncludeDeprecated: tea.String("true"),
 	}
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.ListSecretVersionIds(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				logQueryError(ctx, d, h, "alicloud_kms_key.listKmsSecretVersionIds", err, "request", request)
-				return err
-			}
-		}
-		return nil
-	})
+	response, err = client.ListSecretVersionIds(request)
 	if err != nil {
-		plugin.Logger(ctx).Error("alicloud_kms_key.listKmsSecretVersionIds", "retry_query_error", err, "request", request)
+		logQueryError(ctx, d, h, "alicloud_kms_key.listKmsSecretVersionIds", err, "request", request)
 		return nil, err
 	}
 
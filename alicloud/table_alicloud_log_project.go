@@ -183,8 +183,7 @@ func getLogProjectAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 
 	data := h.Item.(*sls.LogProject)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
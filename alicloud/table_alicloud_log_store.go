@@ -18,6 +18,7 @@ func tableAlicloudLogStore(ctx context.Context) *plugin.Table {
 		Description: "Alicloud Log Service (SLS) Logstore.",
 		List: &plugin.ListConfig{
 			ParentHydrate: listLogProjects,
+			ParentTags:    map[string]string{"service": "sls", "action": "ListProjectV2"},
 			Hydrate:       listLogstores,
 			Tags:          map[string]string{"service": "sls", "action": "ListLogStoreV2"},
 		},
@@ -283,8 +284,7 @@ func getLogstoreAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 
 	data := h.Item.(logstoreItem)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
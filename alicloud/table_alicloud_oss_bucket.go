@@ -47,6 +47,7 @@ func tableAlicloudOssBucket(ctx context.Context) *plugin.Table {
 				Tags: map[string]string{"service": "oss", "action": "GetBucketPublicAccessBlock"},
 			},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "name",
@@ -0,0 +1,242 @@
+package alicloud
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// pluginPermission is a RAM action needed by a table, to list or get its rows or to hydrate a column
+type pluginPermission struct {
+	TableName string
+	// ColumnName is empty for the matrix, list and get calls, which are needed for every column
+	ColumnName string
+	Call       string
+	Hydrate    string
+	Service    string
+	Action     string
+	RamAction  string
+}
+
+// ramActionServices are the services whose RAM actions have a different prefix than the service tag of the hydrate calls
+var ramActionServices = map[string]string{
+	"cas": "yundun-cert",
+	"ims": "ram",
+	"sas": "yundun-sas",
+	"sls": "log",
+}
+
+// ramActionOverrides are the API actions which are authorized by a RAM action of another name
+var ramActionOverrides = map[string]string{
+	"log:ListLogStoreV2": "log:ListLogStores",
+	"log:ListProjectV2":  "log:ListProject",
+	"oss:GetBucketTags":  "oss:GetBucketTagging",
+}
+
+//// TABLE DEFINITION
+
+func tableAlicloudPluginPermission(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "alicloud_plugin_permission",
+		Description: "RAM actions needed by the tables of the plugin, to list or get their rows and to hydrate their columns.",
+		List: &plugin.ListConfig{
+			Hydrate: listPluginPermissions,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "table_name", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "table_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the table, e.g. alicloud_ecs_instance.",
+			},
+			{
+				Name:        "column_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The column which needs the action. Null for the matrix, credentials, list and get calls, which are needed by every column.",
+			},
+			{
+				Name:        "call",
+				Type:        proto.ColumnType_STRING,
+				Description: "The call which needs the action. Valid values: matrix, credentials, parent_list, list, get and hydrate. Matrix calls find the regions and accounts the connection queries, credentials calls assume the roles of role_arn and member_role_name. Get calls are made when the key columns of the table are given, e.g. where instance_id = 'i-bp1****'.",
+			},
+			{
+				Name:        "hydrate",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the hydrate, matrix or credentials function of the plugin which makes the call.",
+			},
+			{
+				Name:        "service",
+				Type:        proto.ColumnType_STRING,
+				Description: "The service of the API call, e.g. ecs.",
+			},
+			{
+				Name:        "action",
+				Type:        proto.ColumnType_STRING,
+				Description: "The API action, e.g. DescribeInstances.",
+			},
+			{
+				Name:        "ram_action",
+				Type:        proto.ColumnType_STRING,
+				Description: "The RAM action which authorizes the API call, e.g. ecs:DescribeInstances.",
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listPluginPermissions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	tables := d.Table.Plugin.TableMap
+	if name := d.EqualsQualString("table_name"); name != "" {
+		table, ok := tables[name]
+		if !ok {
+			return nil, nil
+		}
+		tables = map[string]*plugin.Table{name: table}
+	}
+
+	for _, name := range sortedTableNames(tables) {
+		for _, permission := range tablePermissions(d.Connection, tables[name]) {
+			d.StreamListItem(ctx, permission)
+
+			// This will return zero if context has been cancelled (i.e due to manual cancellation) or
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+func sortedTableNames(tables map[string]*plugin.Table) []string {
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tablePermissions returns the RAM actions needed by a table for a connection, from the service and action
+// tags of its hydrate calls. A hydrate call which makes several API calls lists them all in its "actions" tag,
+// comma separated. The STS calls of the connection, which have no tags, are added for its config.
+func tablePermissions(connection *plugin.Connection, table *plugin.Table) []pluginPermission {
+	var permissions []pluginPermission
+	add := func(column, call string, f any, tags map[string]string) {
+		if tags["service"] == "" || tags["action"] == "" {
+			return
+		}
+		actions := []string{tags["action"]}
+		if tags["actions"] != "" {
+			actions = strings.Split(tags["actions"], ",")
+		}
+		for _, action := range actions {
+			permissions = append(permissions, pluginPermission{
+				TableName:  table.Name,
+				ColumnName: column,
+				Call:       call,
+				Hydrate:    helpers.GetFunctionName(f),
+				Service:    tags["service"],
+				Action:     action,
+				RamAction:  ramAction(tags["service"], action),
+			})
+		}
+	}
+
+	if table.GetMatrixItemFunc != nil {
+		alicloudConfig := GetConfig(connection)
+		matrix := helpers.GetFunctionName(table.GetMatrixItemFunc)
+		// The region matrix functions resolve the "regions" argument against the regions of ECS
+		if matrix != helpers.GetFunctionName(BuildAccountList) && alicloudConfig.Regions != nil {
+			add("", "matrix", table.GetMatrixItemFunc, map[string]string{"service": "ecs", "action": "DescribeRegions"})
+		}
+		if matrix == helpers.GetFunctionName(BuildSAERegionList) {
+			add("", "matrix", table.GetMatrixItemFunc, map[string]string{"service": "sae", "action": "DescribeRegions"})
+		}
+		// Every matrix function lists the members of the resource directory for member_accounts = ["*"],
+		// after the account of the connection
+		if slices.Contains(alicloudConfig.MemberAccounts, memberAccountsAll) {
+			add("", "matrix", table.GetMatrixItemFunc, map[string]string{"service": "sts", "action": "GetCallerIdentity"})
+			add("", "matrix", table.GetMatrixItemFunc, map[string]string{"service": "resourcemanager", "action": "ListAccounts"})
+		}
+		// The API calls are made with the credentials of "role_arn", and in member accounts with the credentials
+		// of "member_role_name", once they are told from the account of the connection
+		if alicloudConfig.RoleArn != nil {
+			add("", "credentials", getAssumeRoleCredentialsProvider, map[string]string{"service": "sts", "action": "AssumeRole"})
+		}
+		if isMultiAccountConnection(connection) {
+			add("", "credentials", getAccountCredentialConfig, map[string]string{"service": "sts", "action": "GetCallerIdentity"})
+			add("", "credentials", getAccountCredentialConfig, map[string]string{"service": "sts", "action": "AssumeRole"})
+		}
+	}
+	if list := table.List; list != nil {
+		if list.ParentHydrate != nil {
+			add("", "parent_list", list.ParentHydrate, list.ParentTags)
+		}
+		add("", "list", list.Hydrate, list.Tags)
+	}
+	if get := table.Get; get != nil {
+		add("", "get", get.Hydrate, get.Tags)
+	}
+
+	hydrateConfigs := map[string]plugin.HydrateConfig{}
+	for _, config := range table.HydrateConfig {
+		hydrateConfigs[helpers.GetFunctionName(config.Func)] = config
+	}
+	if get := table.Get; get != nil {
+		// Columns which are not returned by the list call are often hydrated by the get call
+		name := helpers.GetFunctionName(get.Hydrate)
+		if _, ok := hydrateConfigs[name]; !ok {
+			hydrateConfigs[name] = plugin.HydrateConfig{Func: get.Hydrate, Tags: get.Tags}
+		}
+	}
+
+	for _, column := range table.Columns {
+		if column.Hydrate == nil {
+			continue
+		}
+		// The account of a connection without "member_accounts" is looked up with STS, the matrix holds the others
+		if helpers.GetFunctionName(column.Hydrate) == helpers.GetFunctionName(getCommonColumns) && !isMultiAccountConnection(connection) {
+			add(column.Name, "hydrate", getCommonColumns, map[string]string{"service": "sts", "action": "GetCallerIdentity"})
+			continue
+		}
+		// A hydrate call needs the calls it depends on, which run first
+		var visit func(name string, seen map[string]bool)
+		visit = func(name string, seen map[string]bool) {
+			config, ok := hydrateConfigs[name]
+			if !ok || seen[name] {
+				return
+			}
+			seen[name] = true
+			for _, depends := range config.Depends {
+				visit(helpers.GetFunctionName(depends), seen)
+			}
+			add(column.Name, "hydrate", config.Func, config.Tags)
+		}
+		visit(helpers.GetFunctionName(column.Hydrate), map[string]bool{})
+	}
+
+	return permissions
+}
+
+// ramAction returns the RAM action which authorizes an API call, e.g. ecs:DescribeInstances
+func ramAction(service, action string) string {
+	if prefix, ok := ramActionServices[service]; ok {
+		service = prefix
+	}
+	ramAction := service + ":" + action
+	if override, ok := ramActionOverrides[ramAction]; ok {
+		return override
+	}
+	return ramAction
+}
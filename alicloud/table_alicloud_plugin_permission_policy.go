@@ -0,0 +1,94 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+type pluginPermissionPolicy struct {
+	TableNames []string
+	Actions    []string
+	Policy     Policy
+}
+
+//// TABLE DEFINITION
+
+func tableAlicloudPluginPermissionPolicy(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "alicloud_plugin_permission_policy",
+		Description: "A least privilege RAM policy which allows the actions needed to query a set of tables of the plugin.",
+		List: &plugin.ListConfig{
+			Hydrate: listPluginPermissionPolicies,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "table_names", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "table_names",
+				Type:        proto.ColumnType_JSON,
+				Description: "The tables to query, e.g. [\"alicloud_ecs_instance\", \"alicloud_ram_user\"]. All tables of the plugin if not given.",
+			},
+			{
+				Name:        "actions",
+				Type:        proto.ColumnType_JSON,
+				Description: "The RAM actions needed by the tables, sorted by name.",
+			},
+			{
+				Name:        "policy",
+				Type:        proto.ColumnType_JSON,
+				Description: "The RAM policy document which allows the actions, to create a custom policy with.",
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listPluginPermissionPolicies(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	tables := d.Table.Plugin.TableMap
+
+	names := sortedTableNames(tables)
+	if d.EqualsQuals["table_names"] != nil {
+		names = nil
+		if err := json.Unmarshal([]byte(d.EqualsQuals["table_names"].GetJsonbValue()), &names); err != nil {
+			return nil, fmt.Errorf("table_names must be an array of table names: %v", err)
+		}
+	}
+
+	var actions []string
+	for _, name := range names {
+		table, ok := tables[name]
+		if !ok {
+			return nil, fmt.Errorf("%s is not a table of the plugin", name)
+		}
+		for _, permission := range tablePermissions(d.Connection, table) {
+			actions = append(actions, permission.RamAction)
+		}
+	}
+	actions = uniqueStrings(actions)
+	sort.Strings(actions)
+
+	row := pluginPermissionPolicy{
+		TableNames: names,
+		Actions:    actions,
+		Policy:     Policy{Version: "1", Statements: Statements{}},
+	}
+	// Tables which make no API calls, e.g. alicloud_query_diagnostic, need no statement
+	if len(actions) > 0 {
+		row.Policy.Statements = append(row.Policy.Statements, Statement{
+			Effect: "Allow",
+			Action: actions,
+			// The list calls read every resource, so the actions cannot be scoped to resources
+			Resource: CaseSensitiveValue{"*"},
+		})
+	}
+	d.StreamListItem(ctx, row)
+
+	return nil, nil
+}
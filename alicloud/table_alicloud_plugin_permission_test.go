@@ -0,0 +1,212 @@
+package alicloud
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPluginPermissionListsTableActions(t *testing.T) {
+	stub := newOpenAPIStub(t)
+	connection := newTestConnection(t, stub, "")
+
+	columns := []string{"table_name", "column_name", "call", "hydrate", "service", "action", "ram_action"}
+	rows, err := connection.query("alicloud_plugin_permission", columns, []testQual{{Column: "table_name", Value: "alicloud_ram_access_key"}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The last used columns fall back to the credential report, with the logon name of the user
+	var lastUsed []map[string]any
+	for _, column := range []string{"last_used_date", "last_used_service", "days_since_last_used", "last_used_source"} {
+		for _, action := range []string{"GetAccessKeyLastUsed", "GetDefaultDomain", "GetCredentialReport"} {
+			lastUsed = append(lastUsed, map[string]any{
+				"table_name":  "alicloud_ram_access_key",
+				"column_name": column,
+				"call":        "hydrate",
+				"hydrate":     "getRAMAccessKeyLastUsed",
+				"service":     "ims",
+				"action":      action,
+				"ram_action":  "ram:" + action,
+			})
+		}
+	}
+	compareRows(t, columns, append([]map[string]any{
+		{
+			"table_name":  "alicloud_ram_access_key",
+			"column_name": "account_id",
+			"call":        "hydrate",
+			"hydrate":     "getCommonColumns",
+			"service":     "sts",
+			"action":      "GetCallerIdentity",
+			"ram_action":  "sts:GetCallerIdentity",
+		},
+		{
+			"table_name":  "alicloud_ram_access_key",
+			"column_name": nil,
+			"call":        "parent_list",
+			"hydrate":     "listRAMUser",
+			"service":     "ram",
+			"action":      "ListUsers",
+			"ram_action":  "ram:ListUsers",
+		},
+		{
+			"table_name":  "alicloud_ram_access_key",
+			"column_name": nil,
+			"call":        "list",
+			"hydrate":     "listRAMUserAccessKeys",
+			"service":     "ram",
+			"action":      "ListAccessKeys",
+			"ram_action":  "ram:ListAccessKeys",
+		},
+	}, lastUsed...), rows)
+
+	// Every API call of a table is listed, including the matrix calls, which are made to resolve
+	// "regions" and to list the accounts of member_accounts = ["*"], and the credentials calls of member accounts
+	multiAccount := newTestConnection(t, stub, `regions = ["cn-*"]
+member_accounts = ["*"]`)
+
+	cases := map[string][]string{
+		"alicloud_ram_effective_permission": {
+			"matrix BuildAccountList sts:GetCallerIdentity",
+			"matrix BuildAccountList resourcemanager:ListAccounts",
+			"credentials getAccountCredentialConfig sts:GetCallerIdentity",
+			"credentials getAccountCredentialConfig sts:AssumeRole",
+			"list listRAMEffectivePermissions ram:ListPoliciesForUser",
+			"list listRAMEffectivePermissions ram:ListPoliciesForRole",
+			"list listRAMEffectivePermissions ram:ListGroupsForUser",
+			"list listRAMEffectivePermissions ram:ListPoliciesForGroup",
+			"list listRAMEffectivePermissions ram:GetPolicyVersion",
+		},
+		"alicloud_ram_policy_statement": {
+			"matrix BuildAccountList sts:GetCallerIdentity",
+			"matrix BuildAccountList resourcemanager:ListAccounts",
+			"credentials getAccountCredentialConfig sts:GetCallerIdentity",
+			"credentials getAccountCredentialConfig sts:AssumeRole",
+			"parent_list listRAMPolicyVersionParents ram:ListPolicies",
+			"parent_list listRAMPolicyVersionParents ram:GetPolicy",
+			"list listRAMPolicyStatements ram:ListPolicyVersions",
+			"list listRAMPolicyStatements ram:GetPolicyVersion",
+		},
+		"alicloud_ram_policy_version": {
+			"matrix BuildAccountList sts:GetCallerIdentity",
+			"matrix BuildAccountList resourcemanager:ListAccounts",
+			"credentials getAccountCredentialConfig sts:GetCallerIdentity",
+			"credentials getAccountCredentialConfig sts:AssumeRole",
+			"parent_list listRAMPolicyVersionParents ram:ListPolicies",
+			"parent_list listRAMPolicyVersionParents ram:GetPolicy",
+			"list listRAMPolicyVersions ram:ListPolicyVersions",
+			"list listRAMPolicyVersions ram:GetPolicyVersion",
+		},
+		"alicloud_ram_credential_report": {
+			"matrix BuildAccountList sts:GetCallerIdentity",
+			"matrix BuildAccountList resourcemanager:ListAccounts",
+			"credentials getAccountCredentialConfig sts:GetCallerIdentity",
+			"credentials getAccountCredentialConfig sts:AssumeRole",
+			"list listRAMCredentialReports ram:GetCredentialReport",
+			"list listRAMCredentialReports ram:GenerateCredentialReport",
+		},
+		"alicloud_vpc_network_acl_reachability": {
+			"matrix BuildRegionList ecs:DescribeRegions",
+			"matrix BuildRegionList sts:GetCallerIdentity",
+			"matrix BuildRegionList resourcemanager:ListAccounts",
+			"credentials getAccountCredentialConfig sts:GetCallerIdentity",
+			"credentials getAccountCredentialConfig sts:AssumeRole",
+			"list listVpcNetworkACLReachability vpc:DescribeNetworkAcls",
+			"list listVpcNetworkACLReachability vpc:DescribeVSwitches",
+		},
+		"alicloud_sae_application": {
+			"matrix BuildSAERegionList ecs:DescribeRegions",
+			"matrix BuildSAERegionList sae:DescribeRegions",
+			"matrix BuildSAERegionList sts:GetCallerIdentity",
+			"matrix BuildSAERegionList resourcemanager:ListAccounts",
+			"credentials getAccountCredentialConfig sts:GetCallerIdentity",
+			"credentials getAccountCredentialConfig sts:AssumeRole",
+			"list listApplications sae:ListApplications",
+			"get getApplication sae:GetApplication",
+		},
+	}
+
+	callColumns := []string{"call", "hydrate", "ram_action"}
+	for table, calls := range cases {
+		t.Run(table, func(t *testing.T) {
+			rows, err := multiAccount.query("alicloud_plugin_permission", columns, []testQual{{Column: "table_name", Value: table}}, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The calls of the rows, the calls which hydrate columns are not compared
+			var got []map[string]any
+			for _, row := range rows {
+				if row["call"] != "hydrate" {
+					got = append(got, row)
+				}
+			}
+			var want []map[string]any
+			for _, call := range calls {
+				fields := strings.Fields(call)
+				want = append(want, map[string]any{"call": fields[0], "hydrate": fields[1], "ram_action": fields[2]})
+			}
+			compareRows(t, callColumns, want, got)
+		})
+	}
+
+	// The credentials of "role_arn" are assumed for every API call
+	assumeRole := newTestConnection(t, stub, `role_arn = "acs:ram::1234567890123456:role/steampipe"`)
+	rows, err = assumeRole.query("alicloud_plugin_permission", callColumns, []testQual{{Column: "table_name", Value: "alicloud_ram_user"}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var credentials []map[string]any
+	for _, row := range rows {
+		if row["call"] == "credentials" {
+			credentials = append(credentials, row)
+		}
+	}
+	compareRows(t, callColumns, []map[string]any{
+		{"call": "credentials", "hydrate": "getAssumeRoleCredentialsProvider", "ram_action": "sts:AssumeRole"},
+	}, credentials)
+	stub.verify(t)
+}
+
+func TestPluginPermissionPolicyAllowsTableActions(t *testing.T) {
+	stub := newOpenAPIStub(t)
+	connection := newTestConnection(t, stub, "")
+
+	columns := []string{"actions", "policy"}
+	rows, err := connection.query("alicloud_plugin_permission_policy", columns, []testQual{{
+		Column: "table_names",
+		Value:  []string{"alicloud_ram_access_key", "alicloud_log_store", "alicloud_query_diagnostic"},
+	}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actions := []any{
+		"log:GetLogStore",
+		"log:ListLogStores",
+		"log:ListProject",
+		"ram:GetAccessKeyLastUsed",
+		"ram:GetCredentialReport",
+		"ram:GetDefaultDomain",
+		"ram:ListAccessKeys",
+		"ram:ListUsers",
+		"sts:GetCallerIdentity",
+	}
+	compareRows(t, columns, []map[string]any{
+		{
+			"actions": actions,
+			"policy": map[string]any{
+				"Version": "1",
+				"Statement": []any{
+					map[string]any{"Effect": "Allow", "Action": actions, "Resource": []any{"*"}},
+				},
+			},
+		},
+	}, rows)
+
+	_, err = connection.query("alicloud_plugin_permission_policy", columns, []testQual{{Column: "table_names", Value: []string{"alicloud_ecs_vm"}}}, 0)
+	if err == nil || !strings.Contains(err.Error(), "alicloud_ecs_vm is not a table of the plugin") {
+		t.Errorf("expected an error for an unknown table, got %v", err)
+	}
+	stub.verify(t)
+}
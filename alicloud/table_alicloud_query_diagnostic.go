@@ -0,0 +1,105 @@
+package alicloud
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAlicloudQueryDiagnostic(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "alicloud_query_diagnostic",
+		Description: "Errors ignored by earlier queries of the connection, which returned no rows for a region or table instead of failing.",
+		List: &plugin.ListConfig{
+			Hydrate: listQueryDiagnostics,
+		},
+		// The rows change with every query of the connection
+		Cache: &plugin.TableCacheOptions{
+			Enabled: false,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "time",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The time when the error was ignored.",
+			},
+			{
+				Name:        "table_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The table queried when the error occurred.",
+				Transform:   transform.FromField("Table"),
+			},
+			{
+				Name:        "service",
+				Type:        proto.ColumnType_STRING,
+				Description: "The service of the API call which failed, e.g. ecs.",
+			},
+			{
+				Name:        "action",
+				Type:        proto.ColumnType_STRING,
+				Description: "The API action which failed, e.g. DescribeInstances.",
+			},
+			{
+				Name:        "reason",
+				Type:        proto.ColumnType_STRING,
+				Description: "Why the error was ignored: unreachable_region, ignore_error_codes or ignore_error_rules.",
+			},
+			{
+				Name:        "error_code",
+				Type:        proto.ColumnType_STRING,
+				Description: "The error code returned by the API, e.g. Forbidden.RAM.",
+			},
+			{
+				Name:        "status_code",
+				Type:        proto.ColumnType_INT,
+				Description: "The HTTP status code returned by the API.",
+			},
+			{
+				Name:        "request_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the failed API request.",
+			},
+			{
+				Name:        "error_message",
+				Type:        proto.ColumnType_STRING,
+				Description: "The message of the error.",
+			},
+			{
+				Name:        "connection",
+				Type:        proto.ColumnType_STRING,
+				Description: "The connection queried when the error occurred.",
+			},
+
+			// alicloud standard columns
+			{
+				Name:        "region",
+				Type:        proto.ColumnType_STRING,
+				Description: "The Alicloud region queried when the error occurred.",
+			},
+			{
+				Name:        "account_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The Alicloud Account ID queried when the error occurred, for connections with member accounts.",
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listQueryDiagnostics(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	for _, entry := range queryDiagnostics.list(d.Connection.Name) {
+		d.StreamListItem(ctx, entry)
+
+		// This will return zero if context has been cancelled (i.e due to manual cancellation) or
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQueryDiagnosticListsIgnoredErrors(t *testing.T) {
+	stub := newOpenAPIStub(t,
+		&stubInteraction{
+			Service: "ram",
+			Action:  "ListUsers",
+			Body:    json.RawMessage(`{"IsTruncated": false, "Users": {"User": [{"UserName": "alice", "UserId": "111"}]}, "RequestId": "stub"}`),
+		},
+		&stubInteraction{
+			Service:      "ram",
+			Action:       "ListGroupsForUser",
+			Status:       403,
+			ErrorCode:    "NoPermission",
+			ErrorMessage: "You are not authorized to do this action.",
+		},
+		&stubInteraction{
+			Service:      "cs",
+			Action:       "DescribeUserPermission",
+			Method:       "GET",
+			Path:         "/permissions/users/111",
+			Status:       403,
+			ErrorCode:    "Forbidden.RAM",
+			ErrorMessage: "You are not authorized to do this action.",
+		},
+		&stubInteraction{
+			Service:      "ram",
+			Action:       "GetUser",
+			Status:       404,
+			ErrorCode:    "EntityNotExist.User",
+			ErrorMessage: "The user does not exist.",
+		},
+	)
+	connection := newTestConnection(t, stub, `ignore_error_rules = [
+  { service = "ram", error_codes = ["NoPermission"] },
+  { service = "cs", error_codes = ["Forbidden.RAM"] }
+]`)
+
+	// The errors of column hydrate calls are listed with the service and action of the call which failed
+	if _, err := connection.query("alicloud_ram_user", []string{"name", "groups", "cs_user_permissions"}, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	// Resources which are not found are not listed
+	if _, err := connection.query("alicloud_ram_user", []string{"name"}, []testQual{{Column: "name", Value: "bob"}}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	columns := []string{"table_name", "service", "action", "reason", "error_code", "status_code", "request_id", "connection"}
+	rows, err := connection.query("alicloud_query_diagnostic", columns, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareRows(t, columns, []map[string]any{
+		{
+			"table_name":  "alicloud_ram_user",
+			"service":     "ram",
+			"action":      "ListGroupsForUser",
+			"reason":      "ignore_error_rules",
+			"error_code":  "NoPermission",
+			"status_code": float64(403),
+			"request_id":  "stub",
+			"connection":  connection.name,
+		},
+		{
+			"table_name":  "alicloud_ram_user",
+			"service":     "cs",
+			"action":      "DescribeUserPermission",
+			"reason":      "ignore_error_rules",
+			"error_code":  "Forbidden.RAM",
+			"status_code": float64(403),
+			"request_id":  "stub",
+			"connection":  connection.name,
+		},
+	}, rows)
+	stub.verify(t)
+}
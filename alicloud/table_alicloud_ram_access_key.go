@@ -2,7 +2,10 @@ package alicloud
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	ims "github.com/alibabacloud-go/ims-20190815/v4/client"
 	ram "github.com/alibabacloud-go/ram-20150501/v2/client"
 	"github.com/alibabacloud-go/tea/tea"
 
@@ -18,6 +21,17 @@ type accessKeyRow = struct {
 	UserName    string
 }
 
+// imsAPIUnavailableErrorCodes are the errors of an IMS API which is not available to the account or in the site,
+// as opposed to errors of the call, e.g. a missing permission
+var imsAPIUnavailableErrorCodes = []string{"InvalidAction.NotFound", "InvalidVersion", "UnsupportedOperation"}
+
+type accessKeyLastUsed struct {
+	LastUsedDate      *string
+	LastUsedService   *string
+	LastUsedSource    string
+	DaysSinceLastUsed *int64
+}
+
 //// TABLE DEFINITION
 
 func tableAlicloudRAMAccessKey(_ context.Context) *plugin.Table {
@@ -26,9 +40,17 @@ func tableAlicloudRAMAccessKey(_ context.Context) *plugin.Table {
 		Description: "Alibaba Cloud RAM User Access Key.",
 		List: &plugin.ListConfig{
 			ParentHydrate: listRAMUser,
+			ParentTags:    map[string]string{"service": "ram", "action": "ListUsers"},
 			Hydrate:       listRAMUserAccessKeys,
 			Tags:          map[string]string{"service": "ram", "action": "ListAccessKeys"},
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getRAMAccessKeyLastUsed,
+				Tags: map[string]string{"service": "ims", "action": "GetAccessKeyLastUsed", "actions": "GetAccessKeyLastUsed,GetDefaultDomain,GetCredentialReport"},
+			},
+		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "user_name",
@@ -50,6 +72,37 @@ func tableAlicloudRAMAccessKey(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_TIMESTAMP,
 				Description: "The time when the AccessKey pair was created.",
 			},
+			{
+				Name:        "age_days",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of days since the AccessKey pair was created.",
+				Transform:   transform.From(accessKeyAgeDays),
+			},
+			{
+				Name:        "last_used_date",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The time when the AccessKey pair was last used, NULL if it was never used.",
+				Hydrate:     getRAMAccessKeyLastUsed,
+			},
+			{
+				Name:        "last_used_service",
+				Type:        proto.ColumnType_STRING,
+				Description: "The Alibaba Cloud service that was last accessed with the AccessKey pair, e.g. Ecs. Only available when last_used_source is api.",
+				Hydrate:     getRAMAccessKeyLastUsed,
+			},
+			{
+				Name:        "days_since_last_used",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of days since the AccessKey pair was last used, NULL if it was never used.",
+				Hydrate:     getRAMAccessKeyLastUsed,
+				Transform:   transform.FromField("DaysSinceLastUsed"),
+			},
+			{
+				Name:        "last_used_source",
+				Type:        proto.ColumnType_STRING,
+				Description: "Where the last used details come from. Valid values: api (GetAccessKeyLastUsed) and credential_report (the latest credential report). NULL if neither has the AccessKey pair.",
+				Hydrate:     getRAMAccessKeyLastUsed,
+			},
 
 			// steampipe common columns
 			{
@@ -119,14 +172,72 @@ func listRAMUserAccessKeys(ctx context.Context, d *plugin.QueryData, h *plugin.H
 
 //// HYDRATE FUNCTIONS
 
+// getRAMAccessKeyLastUsed gets when and where an access key was last used, from GetAccessKeyLastUsed,
+// or from the latest credential report if the API is not available
+func getRAMAccessKeyLastUsed(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := h.Item.(accessKeyRow)
+
+	// Create service connection
+	client, err := IMSService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ram_access_key.getRAMAccessKeyLastUsed", "connection_error", err)
+		return nil, err
+	}
+
+	principalName, err := getRAMUserPrincipalName(ctx, d, h, key.UserName)
+	if err != nil {
+		logQueryError(ctx, d, h, "alicloud_ram_access_key.getRAMAccessKeyLastUsed", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	request := &ims.GetAccessKeyLastUsedRequest{
+		UserAccessKeyId:   tea.String(key.AccessKeyId),
+		UserPrincipalName: tea.String(principalName),
+	}
+	response, err := client.GetAccessKeyLastUsed(request)
+	if err != nil {
+		if !slices.Contains(imsAPIUnavailableErrorCodes, getAPIErrorDetails(err).Code) {
+			logQueryError(ctx, d, h, "alicloud_ram_access_key.getRAMAccessKeyLastUsed", err, "request", request)
+			return nil, err
+		}
+		plugin.Logger(ctx).Debug("alicloud_ram_access_key.getRAMAccessKeyLastUsed", "api_unavailable", err, "request", request)
+	} else if response.Body != nil && response.Body.AccessKeyLastUsed != nil {
+		lastUsed := response.Body.AccessKeyLastUsed
+		item := &accessKeyLastUsed{LastUsedSource: "api", LastUsedService: lastUsed.ServiceName}
+		if tea.StringValue(lastUsed.LastUsedDate) != "" {
+			item.LastUsedDate = lastUsed.LastUsedDate
+			item.DaysSinceLastUsed = credentialReportDaysSince(now, lastUsed.LastUsedDate)
+		}
+		return item, nil
+	}
+
+	// Fall back to the latest credential report
+	rows, err := getRAMCredentialReportRows(ctx, d, h)
+	if err != nil {
+		logQueryError(ctx, d, h, "alicloud_ram_access_key.getRAMAccessKeyLastUsed", err)
+		return nil, err
+	}
+	if row, ok := rows[key.UserName]; ok {
+		if lastUsedDate, found := row.accessKeyLastUsed(key.CreateDate); found {
+			return &accessKeyLastUsed{
+				LastUsedDate:      lastUsedDate,
+				LastUsedSource:    "credential_report",
+				DaysSinceLastUsed: credentialReportDaysSince(now, lastUsedDate),
+			}, nil
+		}
+	}
+
+	return &accessKeyLastUsed{}, nil
+}
+
 func getAccessKeyArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getAccessKeyArn")
 
 	i := h.Item.(accessKeyRow)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -137,3 +248,10 @@ func getAccessKeyArn(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 
 	return akas, nil
 }
+
+//// TRANSFORM FUNCTIONS
+
+func accessKeyAgeDays(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	key := d.HydrateItem.(accessKeyRow)
+	return credentialReportDaysSince(time.Now(), tea.String(key.CreateDate)), nil
+}
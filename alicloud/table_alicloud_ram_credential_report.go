@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	ims "github.com/alibabacloud-go/ims-20190815/v4/client"
@@ -11,6 +13,7 @@ import (
 	"github.com/gocarina/gocsv"
 	"github.com/sethvargo/go-retry"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
@@ -45,8 +48,21 @@ type alicloudRamCredentialReportResult struct {
 	AdditionalAccessKey3Active      *string `csv:"additional_access_key_3_active"`
 	AdditionalAccessKey3LastRotated *string `csv:"additional_access_key_3_last_rotated"`
 	AdditionalAccessKey3LastUsed    *string `csv:"additional_access_key_3_last_used"`
+
+	// Computed from the report when it is listed
+	PasswordAgeDays             *int64 `csv:"-"`
+	DaysSinceLastLogon          *int64 `csv:"-"`
+	AccessKey1AgeDays           *int64 `csv:"-"`
+	AccessKey1DaysSinceLastUsed *int64 `csv:"-"`
+	AccessKey2AgeDays           *int64 `csv:"-"`
+	AccessKey2DaysSinceLastUsed *int64 `csv:"-"`
+	AnyKeyUnused90d             bool   `csv:"-"`
+	MaxReportAge                *int64 `csv:"-"`
 }
 
+// Number of days after which an active access key which has not been used is reported as unused
+const credentialReportUnusedKeyDays = 90
+
 //// TABLE DEFINITION
 
 func tableAlicloudRAMCredentialReport(ctx context.Context) *plugin.Table {
@@ -55,8 +71,12 @@ func tableAlicloudRAMCredentialReport(ctx context.Context) *plugin.Table {
 		Description: "Alicloud RAM Credential Report",
 		List: &plugin.ListConfig{
 			Hydrate: listRAMCredentialReports,
-			Tags:    map[string]string{"service": "ram", "action": "GetCredentialReport"},
+			Tags:    map[string]string{"service": "ram", "action": "GetCredentialReport", "actions": "GetCredentialReport,GenerateCredentialReport"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "max_report_age", Require: plugin.Optional},
+			},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "user_name",
@@ -224,11 +244,59 @@ func tableAlicloudRAMCredentialReport(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_TIMESTAMP,
 				Transform:   transform.FromGo().NullIfZero().NullIfEqual("N/A").NullIfEqual("-"),
 			},
+			{
+				Name:        "password_age_days",
+				Description: "The number of days since the password was last changed.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("PasswordAgeDays"),
+			},
+			{
+				Name:        "days_since_last_logon",
+				Description: "The number of days since the user last logged in to the console, NULL if the user never logged in.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("DaysSinceLastLogon"),
+			},
+			{
+				Name:        "access_key_1_age_days",
+				Description: "The number of days since the first access key was rotated.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("AccessKey1AgeDays"),
+			},
+			{
+				Name:        "access_key_1_days_since_last_used",
+				Description: "The number of days since the first access key was last used, NULL if it was never used.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("AccessKey1DaysSinceLastUsed"),
+			},
+			{
+				Name:        "access_key_2_age_days",
+				Description: "The number of days since the second access key was rotated.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("AccessKey2AgeDays"),
+			},
+			{
+				Name:        "access_key_2_days_since_last_used",
+				Description: "The number of days since the second access key was last used, NULL if it was never used.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("AccessKey2DaysSinceLastUsed"),
+			},
+			{
+				Name:        "any_key_unused_90d",
+				Description: "Indicates whether any active access key of the user, including the additional access keys, has not been used in the last 90 days, or was rotated more than 90 days ago and never used.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("AnyKeyUnused90d"),
+			},
 			{
 				Name:        "generated_time",
 				Description: "Specifies the time when the credential report has been generated.",
 				Type:        proto.ColumnType_TIMESTAMP,
 			},
+			{
+				Name:        "max_report_age",
+				Description: "The maximum age of the report, in minutes. If the latest report is older, a new report is generated. Defaults to the credential_report_max_age connection argument, if set.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("MaxReportAge"),
+			},
 
 			// alicloud standard columns
 			{
@@ -252,42 +320,97 @@ func listRAMCredentialReports(ctx context.Context, d *plugin.QueryData, h *plugi
 		return nil, err
 	}
 
+	maxReportAge := GetConfig(d.Connection).CredentialReportMaxAge
+	if d.EqualsQuals["max_report_age"] != nil {
+		maxReportAge = tea.Int(int(d.EqualsQuals["max_report_age"].GetInt64Value()))
+	}
+
 	var response *ims.GetCredentialReportResponse
 	req := &ims.GetCredentialReportRequest{}
 	response, err = client.GetCredentialReport(req)
 	if err != nil {
 		if sdkErr, ok := err.(*tea.SDKError); ok && sdkErr.StatusCode != nil && *sdkErr.StatusCode == 404 {
 			plugin.Logger(ctx).Debug("credential report expired or missing. generating a new one...")
-
-			// Trigger generation
-			_, genErr := client.GenerateCredentialReport()
-			if genErr != nil {
-				return nil, fmt.Errorf("failed to trigger new credential report: %w", genErr)
-			}
-
-			// Poll the API until the new report is ready (up to ~55 seconds)
-			b := retry.NewFibonacci(1 * time.Second)
-			err = retry.Do(ctx, retry.WithMaxRetries(10, b), func(ctx context.Context) error {
-				var retryErr error
-				response, retryErr = client.GetCredentialReport(req)
-				if retryErr != nil {
-					// Tell go-retry to back off and try again
-					return retry.RetryableError(retryErr)
-				}
-				return nil
-			})
+			response, err = generateRAMCredentialReport(ctx, client, req, nil)
 			if err != nil {
-				return nil, fmt.Errorf("timed out waiting for credential report generation: %w", err)
+				return nil, err
 			}
 		} else {
 			// If it's a real API error (like 403 Forbidden), let Steampipe handle it (No manual logger!)
 			logQueryError(ctx, d, h, "alicloud_ram_credential_report.listRAMCredentialReports", err)
 			return nil, err
 		}
+	} else if maxReportAge != nil {
+		generatedTime, err := time.Parse(time.RFC3339, tea.StringValue(response.Body.GeneratedTime))
+		if err != nil || time.Since(generatedTime) > time.Duration(*maxReportAge)*time.Minute {
+			plugin.Logger(ctx).Debug("credential report older than max_report_age. generating a new one...", "generated_time", tea.StringValue(response.Body.GeneratedTime), "max_report_age", *maxReportAge)
+			response, err = generateRAMCredentialReport(ctx, client, req, response.Body.GeneratedTime)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
+	rows, err := parseRAMCredentialReport(response)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, row := range rows {
+		row.computeAges(now)
+		if maxReportAge != nil {
+			row.MaxReportAge = tea.Int64(int64(*maxReportAge))
+		}
+		d.StreamListItem(ctx, *row)
+		// This will return zero if context has been cancelled (i.e due to manual cancellation) or
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// generateRAMCredentialReport triggers the generation of a credential report, and waits until a report
+// newer than the previous one, if any, is available
+func generateRAMCredentialReport(ctx context.Context, client *ims.Client, req *ims.GetCredentialReportRequest, previousGeneratedTime *string) (*ims.GetCredentialReportResponse, error) {
+	// Trigger generation
+	_, genErr := client.GenerateCredentialReport()
+	if genErr != nil {
+		return nil, fmt.Errorf("failed to trigger new credential report: %w", genErr)
+	}
+
+	// Poll the API until the new report is ready (up to ~55 seconds)
+	var response *ims.GetCredentialReportResponse
+	b := retry.NewFibonacci(1 * time.Second)
+	err := retry.Do(ctx, retry.WithMaxRetries(10, b), func(ctx context.Context) error {
+		var retryErr error
+		response, retryErr = client.GetCredentialReport(req)
+		if retryErr != nil {
+			// There is no report until the first one is ready. The client retries throttled calls on its own.
+			if sdkErr, ok := retryErr.(*tea.SDKError); ok && sdkErr.StatusCode != nil && *sdkErr.StatusCode == 404 {
+				return retry.RetryableError(retryErr)
+			}
+			return retryErr
+		}
+		// The previous report is returned until the new one is ready
+		if previousGeneratedTime != nil && tea.StringValue(response.Body.GeneratedTime) == *previousGeneratedTime {
+			return retry.RetryableError(fmt.Errorf("credential report generated at %s is not refreshed yet", *previousGeneratedTime))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for credential report generation: %w", err)
+	}
+	return response, nil
+}
+
+// parseRAMCredentialReport decodes the rows of a credential report
+func parseRAMCredentialReport(response *ims.GetCredentialReportResponse) ([]*alicloudRamCredentialReportResult, error) {
 	// The report is Base64-encoded. After decoding the report, the credential report is in the CSV format.
-	data, err := base64.StdEncoding.DecodeString(*response.Body.Content)
+	data, err := base64.StdEncoding.DecodeString(tea.StringValue(response.Body.Content))
 	if err != nil {
 		return nil, err
 	}
@@ -297,22 +420,126 @@ func listRAMCredentialReports(ctx context.Context, d *plugin.QueryData, h *plugi
 	if err := gocsv.UnmarshalString(content, &rows); err != nil {
 		return nil, err
 	}
-
 	for _, row := range rows {
 		row.GeneratedTime = response.Body.GeneratedTime
-		d.StreamListItem(ctx, *row)
-		// This will return zero if context has been cancelled (i.e due to manual cancellation) or
-		// if there is a limit, it will return the number of rows required to reach this limit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
+	}
+	return rows, nil
+}
+
+var getRAMCredentialReportRowsMemoize = plugin.HydrateFunc(getRAMCredentialReportRowsUncached).Memoize(memoize.WithCacheKeyFunction(getRAMCredentialReportRowsCacheKey))
+
+func getRAMCredentialReportRowsCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	cacheKey := accountCacheKey(d, "GetCredentialReport")
+	return cacheKey, nil
+}
+
+// getRAMCredentialReportRows returns the rows of the latest credential report by user name, for other
+// tables to fall back on. Unlike alicloud_ram_credential_report, it does not generate a missing report.
+func getRAMCredentialReportRows(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (map[string]*alicloudRamCredentialReportResult, error) {
+	rows, err := getRAMCredentialReportRowsMemoize(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	return rows.(map[string]*alicloudRamCredentialReportResult), nil
+}
+
+func getRAMCredentialReportRowsUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	client, err := IMSService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("getRAMCredentialReportRowsUncached", "connection_error", err)
+		return nil, err
+	}
+
+	rowsByUser := map[string]*alicloudRamCredentialReportResult{}
+	response, err := client.GetCredentialReport(&ims.GetCredentialReportRequest{})
+	if err != nil {
+		if sdkErr, ok := err.(*tea.SDKError); ok && sdkErr.StatusCode != nil && *sdkErr.StatusCode == 404 {
+			plugin.Logger(ctx).Debug("getRAMCredentialReportRowsUncached", "credential report expired or missing")
+			return rowsByUser, nil
 		}
+		return nil, err
 	}
 
-	return nil, nil
+	rows, err := parseRAMCredentialReport(response)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, row := range rows {
+		row.computeAges(now)
+		// The report names users by their logon name, e.g. alice@123456789012****.onaliyun.com
+		userName, _, _ := strings.Cut(tea.StringValue(row.UserName), "@")
+		rowsByUser[userName] = row
+	}
+	return rowsByUser, nil
+}
+
+// computeAges computes the ages of the credentials of a report row, in days
+func (row *alicloudRamCredentialReportResult) computeAges(now time.Time) {
+	row.PasswordAgeDays = credentialReportDaysSince(now, row.PasswordLastChanged)
+	row.DaysSinceLastLogon = credentialReportDaysSince(now, row.UserLastLogon)
+	row.AccessKey1AgeDays = credentialReportDaysSince(now, row.AccessKey1LastRotated)
+	row.AccessKey1DaysSinceLastUsed = credentialReportDaysSince(now, row.AccessKey1LastUsed)
+	row.AccessKey2AgeDays = credentialReportDaysSince(now, row.AccessKey2LastRotated)
+	row.AccessKey2DaysSinceLastUsed = credentialReportDaysSince(now, row.AccessKey2LastUsed)
+
+	keys := [][3]*string{
+		{row.AccessKey1Active, row.AccessKey1LastRotated, row.AccessKey1LastUsed},
+		{row.AccessKey2Active, row.AccessKey2LastRotated, row.AccessKey2LastUsed},
+		{row.AdditionalAccessKey1Active, row.AdditionalAccessKey1LastRotated, row.AdditionalAccessKey1LastUsed},
+		{row.AdditionalAccessKey2Active, row.AdditionalAccessKey2LastRotated, row.AdditionalAccessKey2LastUsed},
+		{row.AdditionalAccessKey3Active, row.AdditionalAccessKey3LastRotated, row.AdditionalAccessKey3LastUsed},
+	}
+	for _, key := range keys {
+		if active, err := strconv.ParseBool(tea.StringValue(key[0])); err != nil || !active {
+			continue
+		}
+		lastUsed := credentialReportDaysSince(now, key[2])
+		if lastUsed == nil {
+			// Never used, the key is unused once it is older than the threshold
+			lastUsed = credentialReportDaysSince(now, key[1])
+		}
+		if lastUsed != nil && *lastUsed >= credentialReportUnusedKeyDays {
+			row.AnyKeyUnused90d = true
+		}
+	}
 }
 
-// type GetCredentialReportResponse struct {
-// 	RequestId     *string `json:"RequestId,omitempty" xml:"RequestId,omitempty" require:"true"`
-// 	Content       *string `json:"Content,omitempty" xml:"Content,omitempty" require:"true"`
-// 	GeneratedTime *string `json:"GeneratedTime,omitempty" xml:"GeneratedTime,omitempty" require:"true"`
-// }
+// accessKeyLastUsed returns when the access key created at createDate was last used, nil if it was never used.
+// The report does not name access keys, they are found by the time they were rotated, i.e. created.
+func (row *alicloudRamCredentialReportResult) accessKeyLastUsed(createDate string) (*string, bool) {
+	created, err := time.Parse(time.RFC3339, createDate)
+	if err != nil {
+		return nil, false
+	}
+
+	keys := [][2]*string{
+		{row.AccessKey1LastRotated, row.AccessKey1LastUsed},
+		{row.AccessKey2LastRotated, row.AccessKey2LastUsed},
+		{row.AdditionalAccessKey1LastRotated, row.AdditionalAccessKey1LastUsed},
+		{row.AdditionalAccessKey2LastRotated, row.AdditionalAccessKey2LastUsed},
+		{row.AdditionalAccessKey3LastRotated, row.AdditionalAccessKey3LastUsed},
+	}
+	for _, key := range keys {
+		rotated, err := time.Parse(time.RFC3339, tea.StringValue(key[0]))
+		if err != nil || !rotated.Equal(created) {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, tea.StringValue(key[1])); err != nil {
+			return nil, true
+		}
+		return key[1], true
+	}
+	return nil, false
+}
+
+// credentialReportDaysSince returns the number of whole days since a report timestamp, or nil for values such as N/A
+func credentialReportDaysSince(now time.Time, value *string) *int64 {
+	t, err := time.Parse(time.RFC3339, tea.StringValue(value))
+	if err != nil {
+		return nil
+	}
+	days := int64(now.Sub(t) / (24 * time.Hour))
+	return &days
+}
@@ -0,0 +1,350 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ram "github.com/alibabacloud-go/ram-20150501/v2/client"
+	"github.com/alibabacloud-go/tea/tea"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type ramEffectivePermission struct {
+	Principal         string
+	PrincipalType     string
+	PrincipalName     string
+	Action            string
+	Resource          string
+	Context           interface{}
+	Allowed           bool
+	Decision          string
+	Conditional       bool
+	MatchedStatements []ramMatchedStatement
+	EvaluatedPolicies []ramEvaluatedPolicy
+}
+
+type ramEvaluatedPolicy struct {
+	PolicyName  string
+	PolicyType  string
+	VersionId   string
+	AttachedVia string
+}
+
+//// TABLE DEFINITION
+
+func tableAlicloudRamEffectivePermission(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:             "alicloud_ram_effective_permission",
+		Description:      "Alibaba Cloud RAM Effective Permission, whether the policies of a RAM user or role allow an action on a resource.",
+		DefaultTransform: transform.FromCamel(),
+		List: &plugin.ListConfig{
+			Hydrate: listRAMEffectivePermissions,
+			Tags:    map[string]string{"service": "ram", "action": "ListPoliciesForUser", "actions": "ListPoliciesForUser,ListPoliciesForRole,ListGroupsForUser,ListPoliciesForGroup,GetPolicyVersion"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "principal", Require: plugin.Required},
+				{Name: "action", Require: plugin.Required},
+				{Name: "resource", Require: plugin.Required},
+				{Name: "context", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"EntityNotExist.User", "EntityNotExist.Role"}),
+			},
+		},
+		GetMatrixItemFunc: BuildAccountList,
+		Columns: []*plugin.Column{
+			{
+				Name:        "principal",
+				Type:        proto.ColumnType_STRING,
+				Description: "The RAM user or role, as an ARN, e.g. acs:ram::123456789012****:user/alice, as user/<name> or role/<name>, or as a user name. An ARN is only evaluated in its account.",
+			},
+			{
+				Name:        "action",
+				Type:        proto.ColumnType_STRING,
+				Description: "The action, e.g. ecs:DeleteInstance.",
+			},
+			{
+				Name:        "resource",
+				Type:        proto.ColumnType_STRING,
+				Description: "The resource, e.g. acs:ecs:cn-hangzhou:123456789012****:instance/i-bp1****.",
+			},
+			{
+				Name:        "context",
+				Type:        proto.ColumnType_JSON,
+				Description: "The condition keys of the request and their values, e.g. {\"acs:SourceIp\": \"203.0.113.10\", \"acs:MFAPresent\": \"true\"}. Values can be strings or arrays of strings.",
+			},
+			{
+				Name:        "principal_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the principal. Valid values: user and role.",
+			},
+			{
+				Name:        "principal_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the user or role.",
+			},
+			{
+				Name:        "allowed",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the policies of the principal allow the action on the resource.",
+			},
+			{
+				Name:        "decision",
+				Type:        proto.ColumnType_STRING,
+				Description: "The result of the evaluation. Valid values: allowed, explicit_deny (a statement denies the action) and implicit_deny (no statement allows the action).",
+			},
+			{
+				Name:        "conditional",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the decision could change with the values of condition keys missing from context.",
+			},
+			{
+				Name:        "matched_statements",
+				Type:        proto.ColumnType_JSON,
+				Description: "The statements which apply to the action and resource, with their policy, how the policy is attached, and whether their conditions are met, not_met or unknown.",
+			},
+			{
+				Name:        "evaluated_policies",
+				Type:        proto.ColumnType_JSON,
+				Description: "The policies attached to the principal, directly or through its groups.",
+			},
+
+			// Alicloud standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromConstant("global"),
+			},
+			{
+				Name:        "account_id",
+				Description: ColumnDescriptionAccount,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getCommonColumns,
+				Transform:   transform.FromField("AccountID"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listRAMEffectivePermissions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// The qual values are returned as they are, so that the quals match
+	row := &ramEffectivePermission{
+		Principal: d.EqualsQualString("principal"),
+		Action:    d.EqualsQualString("action"),
+		Resource:  d.EqualsQualString("resource"),
+	}
+	if row.Principal == "" || row.Action == "" || row.Resource == "" {
+		return nil, nil
+	}
+
+	principalAccountId, principalType, principalName, err := parseRAMPrincipal(row.Principal)
+	if err != nil {
+		return nil, err
+	}
+	row.PrincipalType, row.PrincipalName = principalType, principalName
+
+	// A principal ARN names the account of the principal, which is not evaluated in the other accounts of the connection
+	if principalAccountId != "" {
+		accountId := d.EqualsQualString(matrixKeyAccount)
+		if accountId == "" {
+			if accountId, err = getConnectionAccountId(ctx, d); err != nil {
+				return nil, err
+			}
+		}
+		if principalAccountId != accountId {
+			return nil, nil
+		}
+	}
+
+	evaluationContext := map[string][]string{}
+	if d.EqualsQuals["context"] != nil {
+		if err := json.Unmarshal([]byte(d.EqualsQuals["context"].GetJsonbValue()), &row.Context); err != nil {
+			return nil, fmt.Errorf("invalid value for context, it must be a JSON object: %v", err)
+		}
+		evaluationContext, err = parseRAMEvaluationContext(row.Context)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Create service connection
+	client, err := RAMService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ram_effective_permission.listRAMEffectivePermissions", "connection_error", err)
+		return nil, err
+	}
+
+	policies, err := getRAMPrincipalPolicies(ctx, d, h, client, row.PrincipalType, row.PrincipalName)
+	if err != nil {
+		return nil, err
+	}
+
+	row.EvaluatedPolicies = []ramEvaluatedPolicy{}
+	for _, policy := range policies {
+		row.EvaluatedPolicies = append(row.EvaluatedPolicies, ramEvaluatedPolicy{
+			PolicyName:  policy.PolicyName,
+			PolicyType:  policy.PolicyType,
+			VersionId:   policy.VersionId,
+			AttachedVia: policy.AttachedVia,
+		})
+	}
+
+	evaluation := evaluateRAMPermission(policies, row.Action, row.Resource, evaluationContext)
+	row.Allowed = evaluation.Decision == ramDecisionAllowed
+	row.Decision, row.Conditional, row.MatchedStatements = evaluation.Decision, evaluation.Conditional, evaluation.MatchedStatements
+
+	d.StreamListItem(ctx, row)
+	return nil, nil
+}
+
+// getRAMPrincipalPolicies returns the policies attached to a user, directly or through its groups, or to a role
+func getRAMPrincipalPolicies(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *ram.Client, principalType string, name string) ([]ramAttachedPolicy, error) {
+	type attachment struct {
+		name, policyType, version, attachedVia string
+	}
+	var attachments []attachment
+
+	// The RAM APIs listing the policies and groups of a principal return all of them in a single page
+	if principalType == "role" {
+		request := &ram.ListPoliciesForRoleRequest{RoleName: tea.String(name)}
+		d.WaitForListRateLimit(ctx)
+		response, err := client.ListPoliciesForRole(request)
+		if err != nil {
+			logQueryError(ctx, d, h, "alicloud_ram_effective_permission.getRAMPrincipalPolicies", err, "request", request)
+			return nil, err
+		}
+		if response.Body.Policies != nil {
+			for _, policy := range response.Body.Policies.Policy {
+				attachments = append(attachments, attachment{tea.StringValue(policy.PolicyName), tea.StringValue(policy.PolicyType), tea.StringValue(policy.DefaultVersion), "role"})
+			}
+		}
+	} else {
+		request := &ram.ListPoliciesForUserRequest{UserName: tea.String(name)}
+		d.WaitForListRateLimit(ctx)
+		response, err := client.ListPoliciesForUser(request)
+		if err != nil {
+			logQueryError(ctx, d, h, "alicloud_ram_effective_permission.getRAMPrincipalPolicies", err, "request", request)
+			return nil, err
+		}
+		if response.Body.Policies != nil {
+			for _, policy := range response.Body.Policies.Policy {
+				attachments = append(attachments, attachment{tea.StringValue(policy.PolicyName), tea.StringValue(policy.PolicyType), tea.StringValue(policy.DefaultVersion), "user"})
+			}
+		}
+
+		groupsRequest := &ram.ListGroupsForUserRequest{UserName: tea.String(name)}
+		d.WaitForListRateLimit(ctx)
+		groupsResponse, err := client.ListGroupsForUser(groupsRequest)
+		if err != nil {
+			logQueryError(ctx, d, h, "alicloud_ram_effective_permission.getRAMPrincipalPolicies", err, "request", groupsRequest)
+			return nil, err
+		}
+		if groupsResponse.Body.Groups != nil {
+			for _, group := range groupsResponse.Body.Groups.Group {
+				groupRequest := &ram.ListPoliciesForGroupRequest{GroupName: group.GroupName}
+				d.WaitForListRateLimit(ctx)
+				groupResponse, err := client.ListPoliciesForGroup(groupRequest)
+				if err != nil {
+					logQueryError(ctx, d, h, "alicloud_ram_effective_permission.getRAMPrincipalPolicies", err, "request", groupRequest)
+					return nil, err
+				}
+				if groupResponse.Body.Policies == nil {
+					continue
+				}
+				for _, policy := range groupResponse.Body.Policies.Policy {
+					attachments = append(attachments, attachment{tea.StringValue(policy.PolicyName), tea.StringValue(policy.PolicyType), tea.StringValue(policy.DefaultVersion), "group/" + tea.StringValue(group.GroupName)})
+				}
+			}
+		}
+	}
+
+	// A policy can be attached through several groups, its document is fetched once
+	documents := map[string]Policy{}
+	policies := make([]ramAttachedPolicy, 0, len(attachments))
+	for _, a := range attachments {
+		key := a.policyType + "/" + a.name
+		document, ok := documents[key]
+		if !ok {
+			request := &ram.GetPolicyVersionRequest{
+				PolicyName: tea.String(a.name),
+				PolicyType: tea.String(a.policyType),
+				VersionId:  tea.String(a.version),
+			}
+			d.WaitForListRateLimit(ctx)
+			response, err := client.GetPolicyVersion(request)
+			if err != nil {
+				logQueryError(ctx, d, h, "alicloud_ram_effective_permission.getRAMPrincipalPolicies", err, "request", request)
+				return nil, err
+			}
+			if response.Body.PolicyVersion != nil && tea.StringValue(response.Body.PolicyVersion.PolicyDocument) != "" {
+				canonical, err := canonicalPolicy(tea.StringValue(response.Body.PolicyVersion.PolicyDocument))
+				if err != nil {
+					return nil, err
+				}
+				document = canonical.(Policy)
+			}
+			documents[key] = document
+		}
+
+		policies = append(policies, ramAttachedPolicy{
+			PolicyName:  a.name,
+			PolicyType:  a.policyType,
+			VersionId:   a.version,
+			AttachedVia: a.attachedVia,
+			Document:    document,
+		})
+	}
+
+	return policies, nil
+}
+
+// parseRAMPrincipal parses a principal such as acs:ram::123456789012****:user/alice, role/admin or alice
+// into its account, empty unless the principal is an ARN, its type and its name
+func parseRAMPrincipal(principal string) (string, string, string, error) {
+	var accountId string
+	if strings.HasPrefix(principal, "acs:") {
+		parts := strings.SplitN(principal, ":", 5)
+		if len(parts) != 5 || parts[3] == "" {
+			return "", "", "", fmt.Errorf("invalid value for principal, it must be a RAM user or role ARN, e.g. acs:ram::123456789012****:user/alice: %s", principal)
+		}
+		accountId, principal = parts[3], parts[4]
+	}
+
+	principalType, name, found := strings.Cut(principal, "/")
+	if !found {
+		return accountId, "user", principal, nil
+	}
+	principalType = strings.ToLower(principalType)
+	if (principalType != "user" && principalType != "role") || name == "" {
+		return "", "", "", fmt.Errorf("invalid value for principal, it must be a RAM user or role, e.g. user/alice or role/admin: %s", principal)
+	}
+	return accountId, principalType, name, nil
+}
+
+// parseRAMEvaluationContext converts the context of an evaluation to lower case condition keys and arrays of values
+func parseRAMEvaluationContext(context interface{}) (map[string][]string, error) {
+	keys, ok := context.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid value for context, it must be a JSON object")
+	}
+
+	evaluationContext := map[string][]string{}
+	for key, value := range keys {
+		if value == nil {
+			continue
+		}
+		values, err := toSliceOfStrings(value)
+		if err != nil {
+			return nil, err
+		}
+		evaluationContext[strings.ToLower(key)] = values
+	}
+	return evaluationContext, nil
+}
@@ -43,6 +43,7 @@ func tableAlicloudRAMGroup(ctx context.Context) *plugin.Table {
 				Tags: map[string]string{"service": "ram", "action": "ListPoliciesForGroup"},
 			},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			// Top columns
 			{
@@ -256,8 +257,7 @@ func getGroupArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 	data := h.Item.(groupInfo)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
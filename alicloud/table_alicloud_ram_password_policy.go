@@ -20,6 +20,7 @@ func tableAlicloudRamPasswordPolicy(_ context.Context) *plugin.Table {
 			Hydrate: listRAMPasswordPolicy,
 			Tags:    map[string]string{"service": "ram", "action": "GetPasswordPolicy"},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "hard_expiry",
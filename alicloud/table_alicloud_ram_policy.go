@@ -3,11 +3,9 @@ package alicloud
 import (
 	"context"
 	"slices"
-	"time"
 
 	ram "github.com/alibabacloud-go/ram-20150501/v2/client"
 	"github.com/alibabacloud-go/tea/tea"
-	"github.com/sethvargo/go-retry"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -36,6 +34,7 @@ func tableAlicloudRamPolicy(_ context.Context) *plugin.Table {
 				{Name: "policy_type", Require: plugin.Optional},
 			},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "policy_name",
@@ -208,21 +207,7 @@ func getRAMPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 	}
 	var response *ram.GetPolicyResponse
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(10, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.GetPolicy(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if *serverErr.Code == "Throttling.User" {
-					return retry.RetryableError(err)
-				}
-				return err
-			}
-		}
-		return nil
-	})
+	response, err = client.GetPolicy(request)
 	if err != nil {
 		return nil, err
 	}
@@ -239,8 +224,7 @@ func getPolicyAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 	data := policyName(h.Item)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
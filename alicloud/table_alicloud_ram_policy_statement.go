@@ -0,0 +1,371 @@
+package alicloud
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	ram "github.com/alibabacloud-go/ram-20150501/v2/client"
+	"github.com/alibabacloud-go/tea/tea"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type ramPolicyStatement struct {
+	PolicyName       *string
+	PolicyType       *string
+	VersionId        *string
+	IsDefaultVersion *bool
+	// Index of the statement in the Statement array of the policy document, starting at 0
+	StatementIndex int
+	Statement      Statement
+
+	AllowsAllActions           bool
+	AllowsAllRamActions        bool
+	AllowsAllResources         bool
+	AllowsNotAction            bool
+	AllowsPrivilegeEscalation  bool
+	PrivilegeEscalationActions []string
+}
+
+//// TABLE DEFINITION
+
+func tableAlicloudRamPolicyStatement(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:             "alicloud_ram_policy_statement",
+		Description:      "Alibaba Cloud RAM Policy Statement",
+		DefaultTransform: transform.FromCamel(),
+		List: &plugin.ListConfig{
+			ParentHydrate: listRAMPolicyVersionParents,
+			ParentTags:    map[string]string{"service": "ram", "action": "ListPolicies", "actions": "ListPolicies,GetPolicy"},
+			Hydrate:       listRAMPolicyStatements,
+			Tags:          map[string]string{"service": "ram", "action": "ListPolicyVersions", "actions": "ListPolicyVersions,GetPolicyVersion"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "policy_name", Require: plugin.Optional},
+				{Name: "policy_type", Require: plugin.Optional},
+				{Name: "is_default_version", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: BuildAccountList,
+		Columns: []*plugin.Column{
+			{
+				Name:        "policy_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the policy.",
+			},
+			{
+				Name:        "policy_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the policy. Valid values: System and Custom.",
+			},
+			{
+				Name:        "version_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the policy version, e.g. v1.",
+			},
+			{
+				Name:        "is_default_version",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the policy version is the default version, i.e. the version in effect.",
+			},
+			{
+				Name:        "statement_index",
+				Type:        proto.ColumnType_INT,
+				Description: "The index of the statement in the Statement array of the policy document, starting at 0.",
+			},
+			{
+				Name:        "sid",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the statement.",
+				Transform:   transform.FromField("Statement.Sid"),
+			},
+			{
+				Name:        "effect",
+				Type:        proto.ColumnType_STRING,
+				Description: "The effect of the statement. Valid values: Allow and Deny.",
+				Transform:   transform.FromField("Statement.Effect"),
+			},
+			{
+				Name:        "action",
+				Type:        proto.ColumnType_JSON,
+				Description: "The actions the statement applies to, in lower case.",
+				Transform:   transform.FromField("Statement.Action"),
+			},
+			{
+				Name:        "not_action",
+				Type:        proto.ColumnType_JSON,
+				Description: "The actions the statement does not apply to, in lower case.",
+				Transform:   transform.FromField("Statement.NotAction"),
+			},
+			{
+				Name:        "resource",
+				Type:        proto.ColumnType_JSON,
+				Description: "The resources the statement applies to.",
+				Transform:   transform.FromField("Statement.Resource"),
+			},
+			{
+				Name:        "not_resource",
+				Type:        proto.ColumnType_JSON,
+				Description: "The resources the statement does not apply to.",
+				Transform:   transform.FromField("Statement.NotResource"),
+			},
+			{
+				Name:        "principal",
+				Type:        proto.ColumnType_JSON,
+				Description: "The principals the statement applies to, for trust policies.",
+				Transform:   transform.FromField("Statement.Principal"),
+			},
+			{
+				Name:        "not_principal",
+				Type:        proto.ColumnType_JSON,
+				Description: "The principals the statement does not apply to, for trust policies.",
+				Transform:   transform.FromField("Statement.NotPrincipal"),
+			},
+			{
+				Name:        "condition",
+				Type:        proto.ColumnType_JSON,
+				Description: "The conditions of the statement, with the condition keys in lower case and the values as arrays.",
+				Transform:   transform.FromField("Statement.Condition"),
+			},
+			{
+				Name:        "allows_all_actions",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the statement allows all actions, i.e. * or *:*.",
+			},
+			{
+				Name:        "allows_all_ram_actions",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the statement allows all RAM actions, e.g. with ram:*, * or a NotAction which does not exclude RAM actions.",
+			},
+			{
+				Name:        "allows_all_resources",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the statement allows actions on all resources, i.e. *.",
+			},
+			{
+				Name:        "allows_not_action",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the statement allows every action except those in NotAction, which also allows actions added to Alibaba Cloud later.",
+			},
+			{
+				Name:        "allows_privilege_escalation",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the statement allows any of the actions in privilege_escalation_actions.",
+			},
+			{
+				Name:        "privilege_escalation_actions",
+				Type:        proto.ColumnType_JSON,
+				Description: "The actions allowed by the statement which let a principal grant itself more permissions, e.g. ram:passrole, ram:attachpolicytouser or ram:createpolicyversion.",
+			},
+			{
+				Name:        "statement",
+				Type:        proto.ColumnType_JSON,
+				Description: "The statement in a canonical form.",
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(ramPolicyStatementTitle),
+			},
+
+			// Alicloud standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromConstant("global"),
+			},
+			{
+				Name:        "account_id",
+				Description: ColumnDescriptionAccount,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getCommonColumns,
+				Transform:   transform.FromField("AccountID"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listRAMPolicyStatements(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	policy := h.Item.(ram.ListPoliciesResponseBodyPoliciesPolicy)
+
+	if name := d.EqualsQualString("policy_name"); name != "" && name != tea.StringValue(policy.PolicyName) {
+		return nil, nil
+	}
+
+	// Create service connection
+	client, err := RAMService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ram_policy_statement.listRAMPolicyStatements", "connection_error", err)
+		return nil, err
+	}
+
+	versions, err := listRAMPolicyVersionDocuments(ctx, d, h, client, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		if d.EqualsQuals["is_default_version"] != nil && d.EqualsQuals["is_default_version"].GetBoolValue() != tea.BoolValue(version.IsDefaultVersion) {
+			continue
+		}
+		if tea.StringValue(version.PolicyDocument) == "" {
+			continue
+		}
+
+		document, err := canonicalPolicy(tea.StringValue(version.PolicyDocument))
+		if err != nil {
+			plugin.Logger(ctx).Error("alicloud_ram_policy_statement.listRAMPolicyStatements", "policy_name", tea.StringValue(policy.PolicyName), "version_id", tea.StringValue(version.VersionId), "err", err)
+			return nil, err
+		}
+
+		for i, statement := range document.(Policy).Statements {
+			d.StreamListItem(ctx, newRAMPolicyStatement(policy, version, i, statement))
+			// This will return zero if context has been cancelled (i.e due to manual cancellation) or
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// listRAMPolicyVersionParents lists the policies whose versions are listed, the parent of alicloud_ram_policy_version
+// and alicloud_ram_policy_statement. A policy_name qual is looked up with GetPolicy, in each type unless policy_type
+// is set, instead of listing about 1,500 system policies.
+func listRAMPolicyVersionParents(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("policy_name")
+	if name == "" {
+		return listRAMPolicies(ctx, d, h)
+	}
+
+	policyTypes := []string{"Custom", "System"}
+	if policyType := d.EqualsQualString("policy_type"); policyType != "" {
+		if !slices.Contains(policyTypes, policyType) {
+			return nil, nil
+		}
+		policyTypes = []string{policyType}
+	}
+
+	// Create service connection
+	client, err := RAMService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("listRAMPolicyVersionParents", "connection_error", err)
+		return nil, err
+	}
+
+	for _, policyType := range policyTypes {
+		request := &ram.GetPolicyRequest{
+			PolicyName: tea.String(name),
+			PolicyType: tea.String(policyType),
+		}
+		d.WaitForListRateLimit(ctx)
+		response, err := client.GetPolicy(request)
+		if err != nil {
+			// The policy is of the other type, or does not exist
+			if getAPIErrorDetails(err).Code == "EntityNotExist.Policy" {
+				continue
+			}
+			logQueryError(ctx, d, h, "listRAMPolicyVersionParents", err, "request", request)
+			return nil, err
+		}
+		if response.Body.Policy == nil {
+			continue
+		}
+
+		policy := response.Body.Policy
+		d.StreamListItem(ctx, ram.ListPoliciesResponseBodyPoliciesPolicy{
+			AttachmentCount: policy.AttachmentCount,
+			CreateDate:      policy.CreateDate,
+			DefaultVersion:  policy.DefaultVersion,
+			Description:     policy.Description,
+			PolicyName:      policy.PolicyName,
+			PolicyType:      policy.PolicyType,
+			UpdateDate:      policy.UpdateDate,
+		})
+	}
+	return nil, nil
+}
+
+// listRAMPolicyVersionDocuments returns every version of a policy with its document
+func listRAMPolicyVersionDocuments(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *ram.Client, policy ram.ListPoliciesResponseBodyPoliciesPolicy) ([]*ram.ListPolicyVersionsResponseBodyPolicyVersionsPolicyVersion, error) {
+	request := &ram.ListPolicyVersionsRequest{
+		PolicyName: policy.PolicyName,
+		PolicyType: policy.PolicyType,
+	}
+
+	d.WaitForListRateLimit(ctx)
+	response, err := client.ListPolicyVersions(request)
+	if err != nil {
+		logQueryError(ctx, d, h, "listRAMPolicyVersionDocuments", err, "request", request)
+		return nil, err
+	}
+	if response.Body.PolicyVersions == nil {
+		return nil, nil
+	}
+
+	versions := response.Body.PolicyVersions.PolicyVersion
+	for _, version := range versions {
+		if tea.StringValue(version.PolicyDocument) != "" {
+			continue
+		}
+
+		// The document is not always included in the list of versions
+		versionRequest := &ram.GetPolicyVersionRequest{
+			PolicyName: policy.PolicyName,
+			PolicyType: policy.PolicyType,
+			VersionId:  version.VersionId,
+		}
+		d.WaitForListRateLimit(ctx)
+		versionResponse, err := client.GetPolicyVersion(versionRequest)
+		if err != nil {
+			logQueryError(ctx, d, h, "listRAMPolicyVersionDocuments", err, "request", versionRequest)
+			return nil, err
+		}
+		if versionResponse.Body.PolicyVersion != nil {
+			version.PolicyDocument = versionResponse.Body.PolicyVersion.PolicyDocument
+		}
+	}
+
+	return versions, nil
+}
+
+// newRAMPolicyStatement computes the risk flags of a policy statement
+func newRAMPolicyStatement(policy ram.ListPoliciesResponseBodyPoliciesPolicy, version *ram.ListPolicyVersionsResponseBodyPolicyVersionsPolicyVersion, index int, statement Statement) *ramPolicyStatement {
+	row := &ramPolicyStatement{
+		PolicyName:                 policy.PolicyName,
+		PolicyType:                 policy.PolicyType,
+		VersionId:                  version.VersionId,
+		IsDefaultVersion:           version.IsDefaultVersion,
+		StatementIndex:             index,
+		Statement:                  statement,
+		AllowsAllActions:           statementAllowsAllActions(statement),
+		AllowsAllRamActions:        statementAllowsAllServiceActions(statement, "ram"),
+		AllowsAllResources:         statementAllowsAllResources(statement),
+		AllowsNotAction:            isAllowStatement(statement) && len(statement.NotAction) > 0,
+		PrivilegeEscalationActions: statementPrivilegeEscalationActions(statement),
+	}
+	row.AllowsPrivilegeEscalation = len(row.PrivilegeEscalationActions) > 0
+	return row
+}
+
+//// TRANSFORM FUNCTIONS
+
+func ramPolicyStatementTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	statement := d.HydrateItem.(*ramPolicyStatement)
+
+	// Build resource title
+	title := tea.StringValue(statement.PolicyName) + ":" + tea.StringValue(statement.VersionId)
+	if statement.Statement.Sid != "" {
+		return title + ":" + statement.Statement.Sid, nil
+	}
+	return title + ":" + strconv.Itoa(statement.StatementIndex), nil
+}
@@ -0,0 +1,283 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	ram "github.com/alibabacloud-go/ram-20150501/v2/client"
+	"github.com/alibabacloud-go/tea/tea"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type ramPolicyVersion struct {
+	PolicyName       *string
+	PolicyType       *string
+	VersionId        *string
+	IsDefaultVersion *bool
+	CreateDate       *string
+	PolicyDocument   *string
+	// The canonical document as indented JSON, so that versions can be compared line by line
+	PolicyDocumentStdText *string
+	PreviousVersionId     *string
+	AddedActions          []string
+	RemovedActions        []string
+	AddedResources        []string
+	RemovedResources      []string
+}
+
+//// TABLE DEFINITION
+
+func tableAlicloudRamPolicyVersion(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:             "alicloud_ram_policy_version",
+		Description:      "Alibaba Cloud RAM Policy Version",
+		DefaultTransform: transform.FromCamel(),
+		List: &plugin.ListConfig{
+			ParentHydrate: listRAMPolicyVersionParents,
+			ParentTags:    map[string]string{"service": "ram", "action": "ListPolicies", "actions": "ListPolicies,GetPolicy"},
+			Hydrate:       listRAMPolicyVersions,
+			Tags:          map[string]string{"service": "ram", "action": "ListPolicyVersions", "actions": "ListPolicyVersions,GetPolicyVersion"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "policy_name", Require: plugin.Optional},
+				{Name: "policy_type", Require: plugin.Optional},
+				{Name: "is_default_version", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: BuildAccountList,
+		Columns: []*plugin.Column{
+			{
+				Name:        "policy_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the policy.",
+			},
+			{
+				Name:        "policy_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the policy. Valid values: System and Custom.",
+			},
+			{
+				Name:        "version_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the policy version, e.g. v1.",
+			},
+			{
+				Name:        "is_default_version",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the policy version is the default version, i.e. the version in effect.",
+			},
+			{
+				Name:        "create_date",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The time when the policy version was created.",
+			},
+			{
+				Name:        "policy_document",
+				Type:        proto.ColumnType_JSON,
+				Description: "The document of the policy version.",
+			},
+			{
+				Name:        "policy_document_std",
+				Type:        proto.ColumnType_JSON,
+				Description: "The document of the policy version in a canonical form for easier searching.",
+				Transform:   transform.FromField("PolicyDocument").Transform(policyToCanonical),
+			},
+			{
+				Name:        "policy_document_std_text",
+				Type:        proto.ColumnType_STRING,
+				Description: "The document of the policy version in a canonical form, as indented JSON text, so that versions can be compared line by line.",
+			},
+			{
+				Name:        "previous_version_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the version created before this version, if it still exists.",
+			},
+			{
+				Name:        "added_actions",
+				Type:        proto.ColumnType_JSON,
+				Description: "The actions of Allow statements of this version which are not in the Allow statements of the previous version.",
+			},
+			{
+				Name:        "removed_actions",
+				Type:        proto.ColumnType_JSON,
+				Description: "The actions of Allow statements of the previous version which are not in the Allow statements of this version.",
+			},
+			{
+				Name:        "added_resources",
+				Type:        proto.ColumnType_JSON,
+				Description: "The resources of Allow statements of this version which are not in the Allow statements of the previous version.",
+			},
+			{
+				Name:        "removed_resources",
+				Type:        proto.ColumnType_JSON,
+				Description: "The resources of Allow statements of the previous version which are not in the Allow statements of this version.",
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(ramPolicyVersionTitle),
+			},
+
+			// Alicloud standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromConstant("global"),
+			},
+			{
+				Name:        "account_id",
+				Description: ColumnDescriptionAccount,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getCommonColumns,
+				Transform:   transform.FromField("AccountID"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listRAMPolicyVersions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	policy := h.Item.(ram.ListPoliciesResponseBodyPoliciesPolicy)
+
+	if name := d.EqualsQualString("policy_name"); name != "" && name != tea.StringValue(policy.PolicyName) {
+		return nil, nil
+	}
+
+	// Create service connection
+	client, err := RAMService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ram_policy_version.listRAMPolicyVersions", "connection_error", err)
+		return nil, err
+	}
+
+	versions, err := listRAMPolicyVersionDocuments(ctx, d, h, client, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := newRAMPolicyVersions(policy, versions)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ram_policy_version.listRAMPolicyVersions", "policy_name", tea.StringValue(policy.PolicyName), "err", err)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if d.EqualsQuals["is_default_version"] != nil && d.EqualsQuals["is_default_version"].GetBoolValue() != tea.BoolValue(row.IsDefaultVersion) {
+			continue
+		}
+		d.StreamListItem(ctx, row)
+		// This will return zero if context has been cancelled (i.e due to manual cancellation) or
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+// newRAMPolicyVersions sorts the versions of a policy by version number, and compares each version with the previous one
+func newRAMPolicyVersions(policy ram.ListPoliciesResponseBodyPoliciesPolicy, versions []*ram.ListPolicyVersionsResponseBodyPolicyVersionsPolicyVersion) ([]*ramPolicyVersion, error) {
+	versions = slices.Clone(versions)
+	sort.SliceStable(versions, func(i, j int) bool {
+		return ramPolicyVersionNumber(versions[i].VersionId) < ramPolicyVersionNumber(versions[j].VersionId)
+	})
+
+	var rows []*ramPolicyVersion
+	var previous *ramPolicyVersion
+	var previousActions, previousResources []string
+	for _, version := range versions {
+		row := &ramPolicyVersion{
+			PolicyName:       policy.PolicyName,
+			PolicyType:       policy.PolicyType,
+			VersionId:        version.VersionId,
+			IsDefaultVersion: version.IsDefaultVersion,
+			CreateDate:       version.CreateDate,
+			PolicyDocument:   version.PolicyDocument,
+		}
+
+		var actions, resources []string
+		if document := tea.StringValue(version.PolicyDocument); document != "" {
+			canonical, err := canonicalPolicy(document)
+			if err != nil {
+				return nil, err
+			}
+			text, err := json.MarshalIndent(canonical, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			row.PolicyDocumentStdText = tea.String(string(text))
+			actions, resources = ramPolicyAllowedActionsAndResources(canonical.(Policy))
+		}
+
+		if previous != nil {
+			row.PreviousVersionId = previous.VersionId
+			row.AddedActions, row.RemovedActions = diffSortedStrings(previousActions, actions)
+			row.AddedResources, row.RemovedResources = diffSortedStrings(previousResources, resources)
+		}
+
+		rows = append(rows, row)
+		previous, previousActions, previousResources = row, actions, resources
+	}
+	return rows, nil
+}
+
+// ramPolicyAllowedActionsAndResources returns the sorted actions and resources of the Allow statements of a policy
+func ramPolicyAllowedActionsAndResources(policy Policy) ([]string, []string) {
+	var actions, resources []string
+	for _, statement := range policy.Statements {
+		if !isAllowStatement(statement) {
+			continue
+		}
+		actions = append(actions, statement.Action...)
+		resources = append(resources, statement.Resource...)
+	}
+	actions, resources = uniqueStrings(actions), uniqueStrings(resources)
+	sort.Strings(actions)
+	sort.Strings(resources)
+	return actions, resources
+}
+
+// diffSortedStrings returns the values which are only in after, and the values which are only in before
+func diffSortedStrings(before []string, after []string) ([]string, []string) {
+	added, removed := []string{}, []string{}
+	for _, value := range after {
+		if _, found := slices.BinarySearch(before, value); !found {
+			added = append(added, value)
+		}
+	}
+	for _, value := range before {
+		if _, found := slices.BinarySearch(after, value); !found {
+			removed = append(removed, value)
+		}
+	}
+	return added, removed
+}
+
+// ramPolicyVersionNumber returns the number of a version ID such as v3
+func ramPolicyVersionNumber(versionId *string) int {
+	number, err := strconv.Atoi(strings.TrimPrefix(tea.StringValue(versionId), "v"))
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+//// TRANSFORM FUNCTIONS
+
+func ramPolicyVersionTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	version := d.HydrateItem.(*ramPolicyVersion)
+
+	// Build resource title
+	return tea.StringValue(version.PolicyName) + ":" + tea.StringValue(version.VersionId), nil
+}
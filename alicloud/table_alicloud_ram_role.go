@@ -42,6 +42,7 @@ func tableAlicloudRAMRole(ctx context.Context) *plugin.Table {
 				Tags: map[string]string{"service": "ram", "action": "ListPoliciesForRole"},
 			},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "name",
@@ -20,6 +20,7 @@ func tableAlicloudRAMSecurityPreference(_ context.Context) *plugin.Table {
 			Hydrate: listRAMSecurityPreference,
 			Tags:    map[string]string{"service": "ram", "action": "GetSecurityPreference"},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			{
 				Name:        "allow_user_to_change_password",
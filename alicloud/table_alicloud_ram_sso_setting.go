@@ -0,0 +1,156 @@
+package alicloud
+
+import (
+	"context"
+
+	ims "github.com/alibabacloud-go/ims-20190815/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAlicloudRAMSsoSetting(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "alicloud_ram_sso_setting",
+		Description: "Alibaba Cloud RAM SSO Setting",
+		// Avoid NullIfZero since SSO is disabled (false) by default
+		DefaultTransform: transform.FromCamel(),
+		List: &plugin.ListConfig{
+			Hydrate: listRAMSsoSettings,
+			Tags:    map[string]string{"service": "ims", "action": "GetUserSsoSettings"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getRAMSAMLProviders,
+				Tags: map[string]string{"service": "ims", "action": "ListSAMLProviders"},
+			},
+		},
+		GetMatrixItemFunc: BuildAccountList,
+		Columns: []*plugin.Column{
+			{
+				Name:        "user_sso_enabled",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether user-based SSO is enabled. If it is, RAM users can no longer log on to the console with their password, and must log on through the identity provider.",
+				Transform:   transform.FromField("SsoEnabled"),
+			},
+			{
+				Name:        "sso_login_with_domain",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether the domain name of the account is appended to the user name sent by the identity provider.",
+			},
+			{
+				Name:        "auxiliary_domain",
+				Type:        proto.ColumnType_STRING,
+				Description: "The auxiliary domain name used for user-based SSO.",
+			},
+			{
+				Name:        "authn_sign_algo",
+				Type:        proto.ColumnType_STRING,
+				Description: "The algorithm used to sign the SAML authentication requests, e.g. RSA-SHA256.",
+			},
+			{
+				Name:        "metadata_document",
+				Type:        proto.ColumnType_STRING,
+				Description: "The metadata document of the identity provider for user-based SSO, Base64-encoded.",
+			},
+			{
+				Name:        "role_sso_enabled",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Indicates whether role-based SSO is possible, i.e. whether the account has any SAML identity provider.",
+				Hydrate:     getRAMSAMLProviders,
+				Transform:   transform.From(ramRoleSsoEnabled),
+			},
+			{
+				Name:        "saml_providers",
+				Type:        proto.ColumnType_JSON,
+				Description: "The SAML identity providers of the account, used for role-based SSO.",
+				Hydrate:     getRAMSAMLProviders,
+				Transform:   transform.FromValue(),
+			},
+
+			// alicloud standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromConstant("global"),
+			},
+			{
+				Name:        "account_id",
+				Description: ColumnDescriptionAccount,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getCommonColumns,
+				Transform:   transform.FromField("AccountID"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+// listRAMSsoSettings streams the user-based SSO settings of the account. The application SSO settings are not
+// included, as the IMS 2019-08-15 API has no GetApplicationSsoSettings action.
+
+func listRAMSsoSettings(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	client, err := IMSService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ram_sso_setting.listRAMSsoSettings", "connection_error", err)
+		return nil, err
+	}
+
+	response, err := client.GetUserSsoSettings()
+	if err != nil {
+		logQueryError(ctx, d, h, "alicloud_ram_sso_setting.listRAMSsoSettings", err)
+		return nil, err
+	}
+	if response.Body == nil || response.Body.UserSsoSettings == nil {
+		return nil, nil
+	}
+
+	d.StreamListItem(ctx, *response.Body.UserSsoSettings)
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getRAMSAMLProviders(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	client, err := IMSService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ram_sso_setting.getRAMSAMLProviders", "connection_error", err)
+		return nil, err
+	}
+
+	providers := []*ims.ListSAMLProvidersResponseBodySAMLProvidersSAMLProvider{}
+	request := &ims.ListSAMLProvidersRequest{
+		MaxItems: tea.Int32(100),
+	}
+	for {
+		response, err := client.ListSAMLProviders(request)
+		if err != nil {
+			logQueryError(ctx, d, h, "alicloud_ram_sso_setting.getRAMSAMLProviders", err, "request", request)
+			return nil, err
+		}
+		if response.Body.SAMLProviders != nil {
+			providers = append(providers, response.Body.SAMLProviders.SAMLProvider...)
+		}
+		if !tea.BoolValue(response.Body.IsTruncated) {
+			break
+		}
+		request.Marker = response.Body.Marker
+	}
+
+	return providers, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func ramRoleSsoEnabled(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	providers := d.HydrateItem.([]*ims.ListSAMLProvidersResponseBodySAMLProvidersSAMLProvider)
+	return len(providers) > 0, nil
+}
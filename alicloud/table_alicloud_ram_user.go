@@ -3,14 +3,14 @@ package alicloud
 import (
 	"context"
 	"fmt"
-	"time"
+	"strings"
 
 	ims "github.com/alibabacloud-go/ims-20190815/v4/client"
 	ram "github.com/alibabacloud-go/ram-20150501/v2/client"
 	"github.com/alibabacloud-go/tea/tea"
-	"github.com/sethvargo/go-retry"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
@@ -27,6 +27,11 @@ type userInfo = struct {
 	LastLoginDate string
 }
 
+type ramUserLoginProfile struct {
+	LoginProfile        *ims.GetLoginProfileResponseBodyLoginProfile
+	ConsoleLoginEnabled bool
+}
+
 //// TABLE DEFINITION
 
 func tableAlicloudRAMUser(ctx context.Context) *plugin.Table {
@@ -60,10 +65,19 @@ func tableAlicloudRAMUser(ctx context.Context) *plugin.Table {
 			},
 			{
 				Func:    getRAMUserPasskeys,
-				Tags:    map[string]string{"service": "ims", "action": "getRAMUserPasskeys"},
+				Tags:    map[string]string{"service": "ims", "action": "ListPasskeys", "actions": "ListPasskeys,GetDefaultDomain"},
 				Depends: []plugin.HydrateFunc{getRAMUserMfaDevices},
 			},
+			{
+				Func: getRAMUserLoginProfile,
+				Tags: map[string]string{"service": "ims", "action": "GetLoginProfile", "actions": "GetLoginProfile,GetDefaultDomain"},
+			},
+			{
+				Func: getCsUserPermissions,
+				Tags: map[string]string{"service": "cs", "action": "DescribeUserPermission"},
+			},
 		},
+		GetMatrixItemFunc: BuildAccountList,
 		Columns: []*plugin.Column{
 			// Top columns
 			{
@@ -120,6 +134,34 @@ func tableAlicloudRAMUser(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_TIMESTAMP,
 				Description: "The time when the RAM user was modified.",
 			},
+			{
+				Name:        "console_login_enabled",
+				Description: "Indicates whether the RAM user can log on to the console with a password, i.e. whether it has an active login profile.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getRAMUserLoginProfile,
+				Transform:   transform.FromField("ConsoleLoginEnabled"),
+			},
+			{
+				Name:        "password_reset_required",
+				Description: "Indicates whether the RAM user must reset the password at the next logon. NULL if the user has no login profile.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getRAMUserLoginProfile,
+				Transform:   transform.FromField("LoginProfile.PasswordResetRequired"),
+			},
+			{
+				Name:        "mfa_bind_required",
+				Description: "Indicates whether the RAM user must bind an MFA device at the next logon. NULL if the user has no login profile.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getRAMUserLoginProfile,
+				Transform:   transform.FromField("LoginProfile.MFABindRequired"),
+			},
+			{
+				Name:        "login_profile",
+				Description: "The login profile of the RAM user, NULL if the user cannot log on to the console.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getRAMUserLoginProfile,
+				Transform:   transform.FromField("LoginProfile"),
+			},
 			{
 				Name:        "mfa_enabled",
 				Description: "The MFA status of the user",
@@ -271,23 +313,9 @@ func getRAMUser(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 	}
 	var response *ram.GetUserResponse
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.GetUser(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if serverErr.Code != nil && *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				logQueryError(ctx, d, h, "alicloud_ram_user.getRAMUser", err, "request", request)
-				return err
-			}
-		}
-		return nil
-	})
+	response, err = client.GetUser(request)
 	if err != nil {
+		logQueryError(ctx, d, h, "alicloud_ram_user.getRAMUser", err, "request", request)
 		return nil, err
 	}
 
@@ -348,23 +376,9 @@ func getRAMUserPolicies(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	}
 	var response *ram.ListPoliciesForUserResponse
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.ListPoliciesForUser(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if serverErr.Code != nil && *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				logQueryError(ctx, d, h, "alicloud_ram_group.getRAMUserPolicies", serverErr, "request", request)
-				return nil
-			}
-		}
-		return nil
-	})
+	response, err = client.ListPoliciesForUser(request)
 	if err != nil {
+		logQueryError(ctx, d, h, "alicloud_ram_group.getRAMUserPolicies", err, "request", request)
 		return nil, err
 	}
 
@@ -405,24 +419,21 @@ func getRAMUserPasskeys(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	plugin.Logger(ctx).Trace("getRAMUserPasskeys")
 	data := h.Item.(userInfo)
 
-	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	// Create service connection
+	client, err := IMSService(ctx, d)
 	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ram_user.getRAMUserPasskeys", "connection_error", err)
 		return nil, err
 	}
-	commonColumnData := commonData.(*alicloudCommonColumnData)
-	accountID := commonColumnData.AccountID
 
-	// Create service connection
-	client, err := IMSService(ctx, d)
+	principalName, err := getRAMUserPrincipalName(ctx, d, h, data.UserName)
 	if err != nil {
-		plugin.Logger(ctx).Error("alicloud_ram_user.getRAMUserPasskeys", "connection_error", err)
+		logQueryError(ctx, d, h, "alicloud_ram_user.getRAMUserPasskeys", err)
 		return nil, err
 	}
 
 	response, err := client.ListPasskeys(&ims.ListPasskeysRequest{
-		UserPrincipalName: tea.String(fmt.Sprintf("%s@%s.onaliyun.com", data.UserName, accountID)),
+		UserPrincipalName: tea.String(principalName),
 	})
 	if err != nil {
 		if serverErr, ok := err.(*tea.SDKError); ok {
@@ -439,13 +450,87 @@ func getRAMUserPasskeys(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	return response.Body.Passkeys, nil
 }
 
+// getRAMUserLoginProfile gets the login profile of a user, which only exists if the user can log on to the console
+func getRAMUserLoginProfile(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	data := h.Item.(userInfo)
+
+	// Create service connection
+	client, err := IMSService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_ram_user.getRAMUserLoginProfile", "connection_error", err)
+		return nil, err
+	}
+
+	principalName, err := getRAMUserPrincipalName(ctx, d, h, data.UserName)
+	if err != nil {
+		logQueryError(ctx, d, h, "alicloud_ram_user.getRAMUserLoginProfile", err)
+		return nil, err
+	}
+
+	request := &ims.GetLoginProfileRequest{
+		UserPrincipalName: tea.String(principalName),
+	}
+	response, err := client.GetLoginProfile(request)
+	if err != nil {
+		// Users without a login profile cannot log on to the console
+		if sdkErr, ok := err.(*tea.SDKError); ok && strings.Contains(tea.StringValue(sdkErr.Code), "EntityNotExist.User.LoginProfile") {
+			return &ramUserLoginProfile{}, nil
+		}
+		logQueryError(ctx, d, h, "alicloud_ram_user.getRAMUserLoginProfile", err, "request", request)
+		return nil, err
+	}
+	if response.Body == nil || response.Body.LoginProfile == nil {
+		return &ramUserLoginProfile{}, nil
+	}
+
+	profile := response.Body.LoginProfile
+	return &ramUserLoginProfile{
+		LoginProfile:        profile,
+		ConsoleLoginEnabled: tea.StringValue(profile.Status) == "Active",
+	}, nil
+}
+
+// getRAMUserPrincipalName returns the logon name of a user, e.g. alice@example.onaliyun.com. Its domain is the
+// default domain of the account, which is named after the account alias if one is set, not the account ID.
+func getRAMUserPrincipalName(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, userName string) (string, error) {
+	domain, err := getRAMDefaultDomainMemoize(ctx, d, h)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s@%s", userName, domain.(string)), nil
+}
+
+var getRAMDefaultDomainMemoize = plugin.HydrateFunc(getRAMDefaultDomainUncached).Memoize(memoize.WithCacheKeyFunction(getRAMDefaultDomainCacheKey))
+
+func getRAMDefaultDomainCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	cacheKey := accountCacheKey(d, "GetDefaultDomain")
+	return cacheKey, nil
+}
+
+func getRAMDefaultDomainUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	client, err := IMSService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("getRAMDefaultDomainUncached", "connection_error", err)
+		return nil, err
+	}
+
+	response, err := client.GetDefaultDomain()
+	if err != nil {
+		return nil, err
+	}
+	if response.Body == nil || tea.StringValue(response.Body.DefaultDomainName) == "" {
+		return nil, fmt.Errorf("GetDefaultDomain returned no default domain")
+	}
+	return tea.StringValue(response.Body.DefaultDomainName), nil
+}
+
 func getUserArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getUserAkas")
 	data := h.Item.(userInfo)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,32 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRAMUserListStopsPagingAtLimit(t *testing.T) {
+	stub := newOpenAPIStub(t, &stubInteraction{
+		Service: "ram",
+		Action:  "ListUsers",
+		Body: json.RawMessage(`{
+			"IsTruncated": true,
+			"Marker": "page-2",
+			"Users": {"User": [{"UserName": "alice"}, {"UserName": "bob"}]}
+		}`),
+	})
+	connection := newTestConnection(t, stub, "")
+
+	rows, err := connection.query("alicloud_ram_user", []string{"name"}, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("expected 1 row, got %d", len(rows))
+	}
+	// The second page is not requested once the limit is reached
+	if calls := stub.calls("ram", "ListUsers"); calls != 1 {
+		t.Errorf("expected 1 ListUsers call, got %d", calls)
+	}
+	stub.verify(t)
+}
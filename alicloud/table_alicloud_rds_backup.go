@@ -24,6 +24,7 @@ func tableAlicloudRdsBackup(ctx context.Context) *plugin.Table {
 		Description: "ApsaraDB RDS Backup is a policy expression that defines when and how you want to back up your DB Instances.",
 		List: &plugin.ListConfig{
 			ParentHydrate: listRdsInstances,
+			ParentTags:    map[string]string{"service": "rds", "action": "DescribeDBInstances"},
 			Hydrate:       listRdsBackups,
 			Tags:          map[string]string{"service": "rds", "action": "DescribeBackups"},
 			KeyColumns: []*plugin.KeyColumn{
@@ -19,6 +19,7 @@ func tableAlicloudRdsDatabase(ctx context.Context) *plugin.Table {
 		Description: "Alibaba Cloud ApsaraDB for RDS (Relational Database Service) is a stable and reliable online database service that scales elastically.",
 		List: &plugin.ListConfig{
 			ParentHydrate: listRdsInstances,
+			ParentTags:    map[string]string{"service": "rds", "action": "DescribeDBInstances"},
 			Hydrate:       listRdsdatabases,
 			Tags:          map[string]string{"service": "rds", "action": "DescribeDatabases"},
 			KeyColumns: []*plugin.KeyColumn{
@@ -2,11 +2,9 @@ package alicloud
 
 import (
 	"context"
-	"time"
 
 	rds "github.com/alibabacloud-go/rds-20140815/v16/client"
 	"github.com/alibabacloud-go/tea/tea"
-	"github.com/sethvargo/go-retry"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -43,7 +41,7 @@ func tableAlicloudRdsInstance(ctx context.Context) *plugin.Table {
 			},
 			{
 				Func: getRdsTags,
-				Tags: map[string]string{"service": "rds", "action": "ListTags"},
+				Tags: map[string]string{"service": "rds", "action": "DescribeTags"},
 			},
 			{
 				Func: getSqlCollectorPolicy,
@@ -57,6 +55,14 @@ func tableAlicloudRdsInstance(ctx context.Context) *plugin.Table {
 				Func: getTDEDetails,
 				Tags: map[string]string{"service": "rds", "action": "DescribeDBInstanceTDE"},
 			},
+			{
+				Func: getSqlCollectorRetention,
+				Tags: map[string]string{"service": "rds", "action": "DescribeSQLCollectorRetention"},
+			},
+			{
+				Func: getRdsInstanceEncryptionKey,
+				Tags: map[string]string{"service": "rds", "action": "DescribeDBInstanceEncryptionKey"},
+			},
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: []*plugin.Column{
@@ -525,7 +531,7 @@ func tableAlicloudRdsInstance(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Hydrate:     getRdsInstanceParameters,
 				Transform:   transform.FromValue(),
-				Description: "The list of running parameters for the instance.",
+				Description: "The list of running parameters for the instance. Values of parameters which hold secrets are NULL unless redact_sensitive_columns is false.",
 			},
 			{
 				Name:        "readonly_db_instance_ids",
@@ -662,21 +668,7 @@ func getRdsInstance(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateD
 	}
 	var response *rds.DescribeDBInstanceAttributeResponse
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.DescribeDBInstanceAttribute(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				return err
-			}
-		}
-		return nil
-	})
+	response, err = client.DescribeDBInstanceAttribute(request)
 	if err != nil {
 		return nil, err
 	}
@@ -819,6 +811,9 @@ func getRdsInstanceParameters(ctx context.Context, d *plugin.QueryData, h *plugi
 		logQueryError(ctx, d, h, "getRdsInstanceParameters", err, "request", request)
 		return nil, err
 	}
+	if shouldRedactSensitiveColumns(d.Connection) {
+		redactRDSParameters(response.Body)
+	}
 	return *response.Body, nil
 }
 
@@ -864,8 +859,7 @@ func getRdsInstanceARN(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		instanceID = tea.StringValue(item.DBInstanceId)
 	}
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -18,7 +18,10 @@ func tableAlicloudRdsInstanceMetricConnections(_ context.Context) *plugin.Table
 		Description: "Alicloud RDS Instance Cloud Monitor Metrics - Connections",
 		List: &plugin.ListConfig{
 			ParentHydrate: listRdsInstances,
+			ParentTags:    map[string]string{"service": "rds", "action": "DescribeDBInstances"},
 			Hydrate:       listRdsInstanceMetricConnections,
+			Tags:          map[string]string{"service": "cms", "action": "DescribeMetricList"},
+			KeyColumns:    cmMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: cmMetricColumns(
@@ -18,7 +18,10 @@ func tableAlicloudRdsInstanceMetricConnectionsDaily(_ context.Context) *plugin.T
 		Description: "Alicloud RDS Instance Cloud Monitor Metrics - Connections (Daily)",
 		List: &plugin.ListConfig{
 			ParentHydrate: listRdsInstances,
+			ParentTags:    map[string]string{"service": "rds", "action": "DescribeDBInstances"},
 			Hydrate:       listRdsInstanceMetricConnectionsDaily,
+			Tags:          map[string]string{"service": "cms", "action": "DescribeMetricList"},
+			KeyColumns:    cmMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: cmMetricColumns(
@@ -18,7 +18,10 @@ func tableAlicloudRdsInstanceMetricCpuUtilization(_ context.Context) *plugin.Tab
 		Description: "Alicloud RDS Instance Cloud Monitor Metrics - CPU Utilization",
 		List: &plugin.ListConfig{
 			ParentHydrate: listRdsInstances,
+			ParentTags:    map[string]string{"service": "rds", "action": "DescribeDBInstances"},
 			Hydrate:       listRdsInstanceMetricCpuUtilization,
+			Tags:          map[string]string{"service": "cms", "action": "DescribeMetricList"},
+			KeyColumns:    cmMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: cmMetricColumns(
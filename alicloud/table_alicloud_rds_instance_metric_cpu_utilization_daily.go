@@ -18,7 +18,10 @@ func tableAlicloudRdsInstanceMetricCpuUtilizationDaily(_ context.Context) *plugi
 		Description: "Alicloud RDS Instance Cloud Monitor Metrics - CPU Utilization (Daily)",
 		List: &plugin.ListConfig{
 			ParentHydrate: listRdsInstances,
+			ParentTags:    map[string]string{"service": "rds", "action": "DescribeDBInstances"},
 			Hydrate:       listRdsInstanceMetricCpuUtilizationDaily,
+			Tags:          map[string]string{"service": "cms", "action": "DescribeMetricList"},
+			KeyColumns:    cmMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: cmMetricColumns(
@@ -18,7 +18,10 @@ func tableAlicloudRdsInstanceMetricCpuUtilizationHourly(_ context.Context) *plug
 		Description: "Alicloud RDS Instance Cloud Monitor Metrics - CPU Utilization (Hourly)",
 		List: &plugin.ListConfig{
 			ParentHydrate: listRdsInstances,
+			ParentTags:    map[string]string{"service": "rds", "action": "DescribeDBInstances"},
 			Hydrate:       listRdsInstanceMetricCpuUtilizationHourly,
+			Tags:          map[string]string{"service": "cms", "action": "DescribeMetricList"},
+			KeyColumns:    cmMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: cmMetricColumns(
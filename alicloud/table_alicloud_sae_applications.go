@@ -39,8 +39,8 @@ func tableAlicloudSaeApplication(ctx context.Context) *plugin.Table {
 				Tags: map[string]string{"service": "sae", "action": "DescribeApplicationConfig"},
 			},
 			{
+				// Builds the ARN from the application config, without an API call of its own
 				Func:    getSaeAppArn,
-				Tags:    map[string]string{"service": "sae", "action": "getSaeAppArn"},
 				Depends: []plugin.HydrateFunc{describeApplicationConfig},
 			},
 		},
@@ -253,7 +253,7 @@ func tableAlicloudSaeApplication(ctx context.Context) *plugin.Table {
 			},
 			{
 				Name:        "envs",
-				Description: "The environment variables of the container.",
+				Description: "The environment variables of the container. Unless redact_sensitive_columns is false in the connection config, the values are NULL.",
 				Type:        proto.ColumnType_STRING,
 				Hydrate:     describeApplicationConfig,
 			},
@@ -313,7 +313,7 @@ func tableAlicloudSaeApplication(ctx context.Context) *plugin.Table {
 			},
 			{
 				Name:        "oss_ak_secret",
-				Description: "The AccessKey secret of the OSS bucket.",
+				Description: "The AccessKey secret of the OSS bucket. NULL unless redact_sensitive_columns is false in the connection config.",
 				Type:        proto.ColumnType_STRING,
 				Hydrate:     describeApplicationConfig,
 			},
@@ -812,6 +812,12 @@ func describeApplicationConfig(ctx context.Context, d *plugin.QueryData, h *plug
 		logQueryError(ctx, d, h, "alicloud_sae_application.describeApplicationConfig", serverErr, "app", *id)
 		return nil, serverErr
 	}
+
+	if shouldRedactSensitiveColumns(d.Connection) {
+		response.Body.Data.Envs = redactSAEEnvs(response.Body.Data.Envs)
+		response.Body.Data.OssAkSecret = nil
+	}
+
 	plugin.Logger(ctx).Warn("alicloud_sae_application.describeApplicationConfig", "item", response.Body.Data)
 
 	return response.Body.Data, nil
@@ -826,8 +832,7 @@ func getSaeAppArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 	app := res.(*sae.DescribeApplicationConfigResponseBodyData)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -249,8 +249,7 @@ func getSecurityCenterAssetAkas(ctx context.Context, d *plugin.QueryData, h *plu
 	data := h.Item.(sas.DescribeCloudCenterInstancesResponseBodyInstances)
 	region := d.EqualsQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
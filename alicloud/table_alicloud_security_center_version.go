@@ -177,8 +177,7 @@ func getSecurityCenterVersionAkas(ctx context.Context, d *plugin.QueryData, h *p
 	data := h.Item.(versionInfo)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
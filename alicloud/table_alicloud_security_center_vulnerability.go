@@ -326,8 +326,7 @@ func getSecurityCenterVulnerabilityAkas(ctx context.Context, d *plugin.QueryData
 
 	data := h.Item.(sas.DescribeVulListResponseBodyVulRecords)
 	region := d.EqualsQualString(matrixKeyRegion)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
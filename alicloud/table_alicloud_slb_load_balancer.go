@@ -2,11 +2,9 @@ package alicloud
 
 import (
 	"context"
-	"time"
 
 	slb "github.com/alibabacloud-go/slb-20140515/v4/client"
 	"github.com/alibabacloud-go/tea/tea"
-	"github.com/sethvargo/go-retry"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -300,22 +298,7 @@ func getSlbLoadBalancer(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	}
 	var response *slb.DescribeLoadBalancersResponse
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.DescribeLoadBalancers(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				return err
-			}
-			plugin.Logger(ctx).Error("alicloud_slb_load_balancer.getSlbLoadBalancer", "api_error", err)
-		}
-		return nil
-	})
+	response, err = client.DescribeLoadBalancers(request)
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +18,7 @@ func tableAlicloudSLSAlert(ctx context.Context) *plugin.Table {
 		Description: "Alicloud Log Service (SLS) Alert.",
 		List: &plugin.ListConfig{
 			ParentHydrate: listLogProjects,
+			ParentTags:    map[string]string{"service": "sls", "action": "ListProjectV2"},
 			Hydrate:       listSLSAlerts,
 			Tags:          map[string]string{"service": "sls", "action": "ListAlert"},
 		},
@@ -227,8 +228,7 @@ func getSLSAlertAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 
 	data := h.Item.(slsAlertItem)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -4,11 +4,9 @@ import (
 	"context"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/alibabacloud-go/tea/tea"
 	vpc "github.com/alibabacloud-go/vpc-20160428/v7/client"
-	"github.com/sethvargo/go-retry"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -317,24 +315,9 @@ func getVpcAttributes(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 
 	var response *vpc.DescribeVpcAttributeResponse
 
-	b := retry.NewFibonacci(100 * time.Millisecond)
-
-	err = retry.Do(ctx, retry.WithMaxRetries(5, b), func(ctx context.Context) error {
-		var err error
-		response, err = client.DescribeVpcAttribute(request)
-		if err != nil {
-			if serverErr, ok := err.(*tea.SDKError); ok {
-				if *serverErr.Code == "Throttling" {
-					return retry.RetryableError(err)
-				}
-				logQueryError(ctx, d, h, "alicloud_vpc.getVpcAttributes", err, "request", request)
-				return err
-			}
-		}
-		return nil
-	})
+	response, err = client.DescribeVpcAttribute(request)
 	if err != nil {
-		plugin.Logger(ctx).Error("getVpcAttributes", "retry_query_error", err, "request", request)
+		logQueryError(ctx, d, h, "alicloud_vpc.getVpcAttributes", err, "request", request)
 		return nil, err
 	}
 	return response.Body, nil
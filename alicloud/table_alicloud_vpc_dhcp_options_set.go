@@ -20,11 +20,11 @@ func tableAlicloudVpcDhcpOptionsSet(ctx context.Context) *plugin.Table {
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("dhcp_options_set_id"),
 			Hydrate:    getVpcDhcpOptionsSet,
-			Tags:       map[string]string{"service": "vpc", "action": "DescribeDhcpOptionsSet"},
+			Tags:       map[string]string{"service": "vpc", "action": "GetDhcpOptionsSet"},
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listVpcDhcpOptionsSets,
-			Tags:    map[string]string{"service": "vpc", "action": "DescribeDhcpOptionsSets"},
+			Tags:    map[string]string{"service": "vpc", "action": "ListDhcpOptionsSets"},
 			KeyColumns: plugin.KeyColumnSlice{
 				{Name: "name", Require: plugin.Optional},
 				{Name: "domain_name", Require: plugin.Optional},
@@ -240,8 +240,7 @@ func getVpcDhcpOptionSetAka(ctx context.Context, d *plugin.QueryData, h *plugin.
 	}
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -280,8 +280,7 @@ func getVpcEipArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 	data := h.Item.(vpc.DescribeEipAddressesResponseBodyEipAddressesEipAddress)
 
 	// Get account details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -221,8 +221,7 @@ func getVpcFlowLogAka(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -236,8 +235,7 @@ func getVpcFlowLogAka(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 
 func getVpcFlowLogAccountId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -242,8 +242,7 @@ func getVpcNatGatewayAka(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	ngw := h.Item.(vpc.DescribeNatGatewaysResponseBodyNatGatewaysNatGateway)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +144,10 @@ func listNetworkACLs(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 		PageNumber: tea.Int32(1),
 		RegionId:   tea.String(d.EqualsQualString(matrixKeyRegion)),
 	}
+	// Set by tables which use this function as their parent hydrate, e.g. alicloud_vpc_network_acl_entry
+	if id := d.EqualsQualString("network_acl_id"); id != "" {
+		request.NetworkAclId = tea.String(id)
+	}
 
 	count := 0
 	for {
@@ -210,8 +214,7 @@ func getNetworkACLAka(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	region := d.EqualsQualString(matrixKeyRegion)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,282 @@
+package alicloud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/alibabacloud-go/tea/tea"
+	vpc "github.com/alibabacloud-go/vpc-20160428/v7/client"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// vpcNetworkAclEntry is an inbound or outbound entry of a network ACL
+type vpcNetworkAclEntry struct {
+	NetworkAclId        *string
+	NetworkAclName      *string
+	VpcId               *string
+	NetworkAclEntryId   *string
+	NetworkAclEntryName *string
+	Direction           string
+	// Position of the entry in the entries of its direction, starting at 1. Entries are evaluated in this order.
+	Position          int64
+	Policy            *string
+	Protocol          *string
+	Port              *string
+	SourceCidrIp      *string
+	DestinationCidrIp *string
+	// The source CIDR block of an inbound entry, the destination CIDR block of an outbound entry
+	CidrBlock       *string
+	IpVersion       *string
+	EntryType       *string
+	Description     *string
+	FromPort        *int64
+	ToPort          *int64
+	PortCount       *int64
+	IsPublicIngress bool
+}
+
+//// TABLE DEFINITION
+
+func tableAlicloudVpcNetworkACLEntry(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "alicloud_vpc_network_acl_entry",
+		Description: "Alicloud VPC Network ACL Entry",
+		List: &plugin.ListConfig{
+			ParentHydrate: listNetworkACLs,
+			ParentTags:    map[string]string{"service": "vpc", "action": "DescribeNetworkAcls"},
+			Hydrate:       listNetworkACLEntries,
+			Tags:          map[string]string{"service": "vpc", "action": "DescribeNetworkAcls"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "network_acl_id", Require: plugin.Optional},
+				{Name: "direction", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: BuildRegionList,
+		Columns: []*plugin.Column{
+			{
+				Name:        "network_acl_entry_id",
+				Description: "The ID of the network ACL entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "network_acl_entry_name",
+				Description: "The name of the network ACL entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "network_acl_id",
+				Description: "The ID of the network ACL the entry belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "network_acl_name",
+				Description: "The name of the network ACL the entry belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC of the network ACL.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direction",
+				Description: "The direction of the entry. Possible values are: ingress, and egress.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "position",
+				Description: "The position of the entry among the entries of its direction, starting at 1. Entries are evaluated in this order, and the first entry that matches the traffic applies.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Position"),
+			},
+			{
+				Name:        "policy",
+				Description: "The action of the entry. Possible values are: accept, and drop.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "protocol",
+				Description: "The protocol of the entry. Possible values are: icmp, gre, tcp, udp, and all.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "port",
+				Description: "The port range of the entry as returned by the API, e.g. 22/22, or -1/-1 for all ports.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "from_port",
+				Description: "The first port of the port range. 1 if the entry applies to all ports, NULL for protocols without ports.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("FromPort"),
+			},
+			{
+				Name:        "to_port",
+				Description: "The last port of the port range. 65535 if the entry applies to all ports, NULL for protocols without ports.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ToPort"),
+			},
+			{
+				Name:        "port_count",
+				Description: "The number of ports in the port range, NULL for protocols without ports.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "cidr_block",
+				Description: "The source CIDR block of an inbound entry, or the destination CIDR block of an outbound entry.",
+				Type:        proto.ColumnType_CIDR,
+			},
+			{
+				Name:        "source_cidr_ip",
+				Description: "The source CIDR block of an inbound entry.",
+				Type:        proto.ColumnType_CIDR,
+			},
+			{
+				Name:        "destination_cidr_ip",
+				Description: "The destination CIDR block of an outbound entry.",
+				Type:        proto.ColumnType_CIDR,
+			},
+			{
+				Name:        "ip_version",
+				Description: "The IP version of the entry. Possible values are: IPV4, and IPV6.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entry_type",
+				Description: "The type of the entry. Possible values are: custom, and system.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_public_ingress",
+				Description: "Indicates whether the entry accepts inbound traffic from any IPv4 or IPv6 address, i.e. 0.0.0.0/0 or ::/0.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("IsPublicIngress"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(vpcNetworkACLEntryTitle),
+			},
+
+			// alicloud standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     networkAclRegion,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "account_id",
+				Description: ColumnDescriptionAccount,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getCommonColumns,
+				Transform:   transform.FromField("AccountID"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listNetworkACLEntries(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	acl := h.Item.(vpc.DescribeNetworkAclsResponseBodyNetworkAclsNetworkAcl)
+	direction := d.EqualsQualString("direction")
+
+	for _, entry := range newVpcNetworkAclEntries(acl) {
+		if direction != "" && entry.Direction != direction {
+			continue
+		}
+		d.StreamListItem(ctx, entry)
+		// This will return zero if context has been cancelled (i.e due to manual cancellation) or
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+// newVpcNetworkAclEntries returns the inbound entries of a network ACL followed by its outbound entries,
+// each in evaluation order
+func newVpcNetworkAclEntries(acl vpc.DescribeNetworkAclsResponseBodyNetworkAclsNetworkAcl) []*vpcNetworkAclEntry {
+	var entries []*vpcNetworkAclEntry
+
+	newEntry := func(direction string, position int, cidrBlock *string) *vpcNetworkAclEntry {
+		entry := &vpcNetworkAclEntry{
+			NetworkAclId:   acl.NetworkAclId,
+			NetworkAclName: acl.NetworkAclName,
+			VpcId:          acl.VpcId,
+			Direction:      direction,
+			Position:       int64(position + 1),
+			CidrBlock:      cidrBlock,
+		}
+		entries = append(entries, entry)
+		return entry
+	}
+
+	if acl.IngressAclEntries != nil {
+		for i, item := range acl.IngressAclEntries.IngressAclEntry {
+			entry := newEntry("ingress", i, item.SourceCidrIp)
+			entry.NetworkAclEntryId = item.NetworkAclEntryId
+			entry.NetworkAclEntryName = item.NetworkAclEntryName
+			entry.Policy = item.Policy
+			entry.Protocol = item.Protocol
+			entry.Port = item.Port
+			entry.SourceCidrIp = item.SourceCidrIp
+			entry.IpVersion = item.IpVersion
+			entry.EntryType = item.EntryType
+			entry.Description = item.Description
+		}
+	}
+
+	if acl.EgressAclEntries != nil {
+		for i, item := range acl.EgressAclEntries.EgressAclEntry {
+			entry := newEntry("egress", i, item.DestinationCidrIp)
+			entry.NetworkAclEntryId = item.NetworkAclEntryId
+			entry.NetworkAclEntryName = item.NetworkAclEntryName
+			entry.Policy = item.Policy
+			entry.Protocol = item.Protocol
+			entry.Port = item.Port
+			entry.DestinationCidrIp = item.DestinationCidrIp
+			entry.IpVersion = item.IpVersion
+			entry.EntryType = item.EntryType
+			entry.Description = item.Description
+		}
+	}
+
+	for _, entry := range entries {
+		if fromPort, toPort, ok := parsePortRange(tea.StringValue(entry.Protocol), tea.StringValue(entry.Port)); ok {
+			portCount := toPort - fromPort + 1
+			entry.FromPort, entry.ToPort, entry.PortCount = &fromPort, &toPort, &portCount
+		}
+		entry.IsPublicIngress = entry.Direction == "ingress" &&
+			strings.EqualFold(tea.StringValue(entry.Policy), "accept") &&
+			isAnyAddressCidr(tea.StringValue(entry.CidrBlock))
+	}
+
+	return entries
+}
+
+//// TRANSFORM FUNCTIONS
+
+func vpcNetworkACLEntryTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	entry := d.HydrateItem.(*vpcNetworkAclEntry)
+
+	// Build resource title
+	if name := tea.StringValue(entry.NetworkAclEntryName); name != "" {
+		return name, nil
+	}
+	return tea.StringValue(entry.NetworkAclEntryId), nil
+}
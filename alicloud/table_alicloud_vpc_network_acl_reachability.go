@@ -0,0 +1,314 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/alibabacloud-go/tea/tea"
+	vpc "github.com/alibabacloud-go/vpc-20160428/v7/client"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Protocols of the traffic which can be checked against a network ACL
+var networkAclProtocols = []string{"tcp", "udp", "icmp", "gre", "all"}
+
+type vpcNetworkAclReachability struct {
+	VSwitchId      string
+	Cidr           string
+	Direction      string
+	Protocol       string
+	Port           *int64
+	VpcId          *string
+	NetworkAclId   *string
+	Allowed        bool
+	MatchedEntry   *vpcNetworkAclEntry
+	PartialMatches []*vpcNetworkAclEntry
+	Reason         string
+}
+
+type networkAclMatch int
+
+const (
+	networkAclNoMatch networkAclMatch = iota
+	// The entry applies to some of the traffic, e.g. to a part of the CIDR block or to one of the protocols
+	networkAclPartialMatch
+	networkAclFullMatch
+)
+
+//// TABLE DEFINITION
+
+func tableAlicloudVpcNetworkACLReachability(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "alicloud_vpc_network_acl_reachability",
+		Description: "Alicloud VPC Network ACL Reachability, whether the network ACL of a vSwitch allows traffic from or to a CIDR block.",
+		List: &plugin.ListConfig{
+			Hydrate: listVpcNetworkACLReachability,
+			Tags:    map[string]string{"service": "vpc", "action": "DescribeNetworkAcls", "actions": "DescribeNetworkAcls,DescribeVSwitches"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "vswitch_id", Require: plugin.Required},
+				{Name: "cidr", Require: plugin.Required},
+				{Name: "direction", Require: plugin.Optional},
+				{Name: "protocol", Require: plugin.Optional},
+				{Name: "port", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: BuildRegionList,
+		Columns: []*plugin.Column{
+			{
+				Name:        "vswitch_id",
+				Description: "The ID of the vSwitch.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VSwitchId"),
+			},
+			{
+				Name:        "cidr",
+				Description: "The IP address or CIDR block the traffic comes from (ingress) or goes to (egress).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direction",
+				Description: "The direction of the traffic. Possible values are: ingress, and egress. Defaults to ingress.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "protocol",
+				Description: "The protocol of the traffic. Possible values are: tcp, udp, icmp, gre, and all. Defaults to all, i.e. the traffic is allowed only if every protocol is allowed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "port",
+				Description: "The port of the traffic, for tcp and udp. If not set, the traffic is allowed only if every port is allowed.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC of the vSwitch.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "network_acl_id",
+				Description: "The ID of the network ACL associated with the vSwitch, NULL if the vSwitch has no network ACL.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "allowed",
+				Description: "Indicates whether the network ACL allows the traffic. Always true if the vSwitch has no network ACL.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Allowed"),
+			},
+			{
+				Name:        "reason",
+				Description: "Why the traffic is allowed or denied. Possible values are: no_network_acl, matched_entry, and implicit_deny.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "matched_entry_id",
+				Description: "The ID of the first entry which applies to all of the traffic, and therefore decides whether it is allowed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("MatchedEntry.NetworkAclEntryId"),
+			},
+			{
+				Name:        "matched_entry_name",
+				Description: "The name of the entry which decides whether the traffic is allowed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("MatchedEntry.NetworkAclEntryName"),
+			},
+			{
+				Name:        "matched_entry_position",
+				Description: "The position of the entry which decides whether the traffic is allowed.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("MatchedEntry.Position"),
+			},
+			{
+				Name:        "matched_entry_policy",
+				Description: "The action of the entry which decides whether the traffic is allowed. Possible values are: accept, and drop.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("MatchedEntry.Policy"),
+			},
+			{
+				Name:        "partially_matched_entries",
+				Description: "The entries evaluated before the matched entry which apply to only some of the traffic, e.g. to a part of the CIDR block, to one of the protocols or to some of the ports. Part of the traffic may be allowed or denied by these entries.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("PartialMatches"),
+			},
+
+			// alicloud standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     networkAclRegion,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "account_id",
+				Description: ColumnDescriptionAccount,
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getCommonColumns,
+				Transform:   transform.FromField("AccountID"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcNetworkACLReachability(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	// The qual values are returned as they are, so that the quals match
+	row := &vpcNetworkAclReachability{
+		VSwitchId: d.EqualsQualString("vswitch_id"),
+		Cidr:      d.EqualsQualString("cidr"),
+		Direction: d.EqualsQualString("direction"),
+		Protocol:  d.EqualsQualString("protocol"),
+	}
+	if row.VSwitchId == "" || row.Cidr == "" {
+		return nil, nil
+	}
+	if row.Direction == "" {
+		row.Direction = "ingress"
+	}
+	if row.Protocol == "" {
+		row.Protocol = "all"
+	}
+	if d.EqualsQuals["port"] != nil {
+		port := d.EqualsQuals["port"].GetInt64Value()
+		row.Port = &port
+	}
+
+	cidr, err := parseCidrOrAddress(row.Cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for cidr, it must be an IP address or a CIDR block: %v", err)
+	}
+	direction := strings.ToLower(row.Direction)
+	if direction != "ingress" && direction != "egress" {
+		return nil, fmt.Errorf("invalid value for direction, it must be ingress or egress: %s", row.Direction)
+	}
+	protocol := strings.ToLower(row.Protocol)
+	if !slices.Contains(networkAclProtocols, protocol) {
+		return nil, fmt.Errorf("invalid value for protocol, it must be one of %s: %s", strings.Join(networkAclProtocols, ", "), row.Protocol)
+	}
+	if row.Port != nil && (*row.Port < minPort || *row.Port > maxPort) {
+		return nil, fmt.Errorf("invalid value for port, it must be between %d and %d: %d", minPort, maxPort, *row.Port)
+	}
+
+	// Create service connection
+	client, err := VpcService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("alicloud_vpc_network_acl_reachability.listVpcNetworkACLReachability", "connection_error", err)
+		return nil, err
+	}
+
+	vswitchRequest := &vpc.DescribeVSwitchesRequest{
+		RegionId:  tea.String(region),
+		VSwitchId: tea.String(row.VSwitchId),
+	}
+	d.WaitForListRateLimit(ctx)
+	vswitchResponse, err := client.DescribeVSwitches(vswitchRequest)
+	if err != nil {
+		logQueryError(ctx, d, h, "alicloud_vpc_network_acl_reachability.listVpcNetworkACLReachability", err, "request", vswitchRequest)
+		return nil, err
+	}
+	// The vSwitch is in another region
+	if vswitchResponse.Body.VSwitches == nil || len(vswitchResponse.Body.VSwitches.VSwitch) == 0 {
+		return nil, nil
+	}
+	vswitch := vswitchResponse.Body.VSwitches.VSwitch[0]
+	row.VpcId = vswitch.VpcId
+
+	if tea.StringValue(vswitch.NetworkAclId) == "" {
+		row.Allowed = true
+		row.Reason = "no_network_acl"
+		d.StreamListItem(ctx, row)
+		return nil, nil
+	}
+	row.NetworkAclId = vswitch.NetworkAclId
+
+	aclRequest := &vpc.DescribeNetworkAclsRequest{
+		RegionId:     tea.String(region),
+		NetworkAclId: vswitch.NetworkAclId,
+	}
+	d.WaitForListRateLimit(ctx)
+	aclResponse, err := client.DescribeNetworkAcls(aclRequest)
+	if err != nil {
+		logQueryError(ctx, d, h, "alicloud_vpc_network_acl_reachability.listVpcNetworkACLReachability", err, "request", aclRequest)
+		return nil, err
+	}
+	if aclResponse.Body.NetworkAcls == nil || len(aclResponse.Body.NetworkAcls.NetworkAcl) == 0 {
+		return nil, nil
+	}
+
+	var entries []*vpcNetworkAclEntry
+	for _, entry := range newVpcNetworkAclEntries(*aclResponse.Body.NetworkAcls.NetworkAcl[0]) {
+		if entry.Direction == direction {
+			entries = append(entries, entry)
+		}
+	}
+
+	row.MatchedEntry, row.PartialMatches = evaluateNetworkAclEntries(entries, cidr, protocol, row.Port)
+	if row.MatchedEntry != nil {
+		row.Allowed = strings.EqualFold(tea.StringValue(row.MatchedEntry.Policy), "accept")
+		row.Reason = "matched_entry"
+	} else {
+		row.Reason = "implicit_deny"
+	}
+
+	d.StreamListItem(ctx, row)
+	return nil, nil
+}
+
+// evaluateNetworkAclEntries evaluates the entries of a network ACL in order, and returns the first entry which applies
+// to all of the traffic, and the entries before it which apply to some of the traffic. Traffic which no entry applies to
+// is denied.
+func evaluateNetworkAclEntries(entries []*vpcNetworkAclEntry, cidr netip.Prefix, protocol string, port *int64) (*vpcNetworkAclEntry, []*vpcNetworkAclEntry) {
+	partialMatches := []*vpcNetworkAclEntry{}
+	for _, entry := range entries {
+		switch matchNetworkAclEntry(entry, cidr, protocol, port) {
+		case networkAclFullMatch:
+			return entry, partialMatches
+		case networkAclPartialMatch:
+			partialMatches = append(partialMatches, entry)
+		}
+	}
+	return nil, partialMatches
+}
+
+// matchNetworkAclEntry returns whether an entry applies to all, some or none of the traffic
+func matchNetworkAclEntry(entry *vpcNetworkAclEntry, cidr netip.Prefix, protocol string, port *int64) networkAclMatch {
+	entryCidr, err := parseCidrOrAddress(tea.StringValue(entry.CidrBlock))
+	if err != nil || entryCidr.Addr().Is4() != cidr.Addr().Is4() || !entryCidr.Overlaps(cidr) {
+		return networkAclNoMatch
+	}
+
+	match := networkAclFullMatch
+	// The entry applies to a part of the CIDR block only
+	if entryCidr.Bits() > cidr.Bits() {
+		match = networkAclPartialMatch
+	}
+
+	entryProtocol := strings.ToLower(tea.StringValue(entry.Protocol))
+	switch {
+	case entryProtocol == "all":
+	case protocol == "all":
+		// The entry applies to one of the protocols only
+		match = networkAclPartialMatch
+	case entryProtocol != protocol:
+		return networkAclNoMatch
+	case entry.FromPort != nil && port != nil:
+		if *port < *entry.FromPort || *port > *entry.ToPort {
+			return networkAclNoMatch
+		}
+	case entry.FromPort != nil && (*entry.FromPort != minPort || *entry.ToPort != maxPort):
+		// The entry applies to some of the ports only
+		match = networkAclPartialMatch
+	}
+
+	return match
+}
@@ -19,8 +19,9 @@ func tableAlicloudVpcRouteEntry(ctx context.Context) *plugin.Table {
 		Description: "Alicloud VPC Route Entry",
 		List: &plugin.ListConfig{
 			ParentHydrate: listVpcRouteTable,
+			ParentTags:    map[string]string{"service": "vpc", "action": "DescribeRouteTableList"},
 			Hydrate:       listVpcRouteEntries,
-			Tags:          map[string]string{"service": "vpc", "action": "DescribeRouteEntries"},
+			Tags:          map[string]string{"service": "vpc", "action": "DescribeRouteEntryList"},
 		},
 		GetMatrixItemFunc: BuildRegionList,
 		Columns: []*plugin.Column{
@@ -176,8 +177,7 @@ func getVpcRouteEntryTurbotData(ctx context.Context, d *plugin.QueryData, h *plu
 	data := h.Item.(vpc.DescribeRouteEntryListResponseBodyRouteEntrysRouteEntry)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
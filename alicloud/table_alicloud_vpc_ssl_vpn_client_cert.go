@@ -234,8 +234,7 @@ func getVpcSslVpnClientCertCertAka(ctx context.Context, d *plugin.QueryData, h *
 	data := h.Item.(vpnSslClientCertInfo)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -217,8 +217,7 @@ func getVpnSslServerAka(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	sslServer := h.Item.(vpc.DescribeSslVpnServersResponseBodySslVpnServersSslVpnServer)
 
 	// Get project details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
-	commonData, err := getCommonColumnsCached(ctx, d, h)
+	commonData, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,108 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// tableFixture is a test case of testdata/fixtures/<table>/<case>.json: a query, the API calls
+// it makes and the rows or error it returns.
+type tableFixture struct {
+	Description string `json:"description"`
+	// Config is appended to the connection config, e.g. "auto_retry = true"
+	Config       string             `json:"config,omitempty"`
+	Columns      []string           `json:"columns"`
+	Quals        []testQual         `json:"quals,omitempty"`
+	Limit        int64              `json:"limit,omitempty"`
+	Interactions []*stubInteraction `json:"interactions"`
+	// Rows are compared regardless of their order, on the columns of the query
+	Rows []map[string]any `json:"rows,omitempty"`
+	// Error is a substring of the error of the query, if it is expected to fail
+	Error string `json:"error,omitempty"`
+}
+
+// TestTableFixtures runs the fixtures of every table against the stub
+func TestTableFixtures(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "fixtures", "*", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no fixtures found in testdata/fixtures")
+	}
+
+	for _, path := range paths {
+		table := filepath.Base(filepath.Dir(path))
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+
+		t.Run(table+"/"+name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fixture tableFixture
+			decoder := json.NewDecoder(strings.NewReader(string(data)))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&fixture); err != nil {
+				t.Fatalf("invalid fixture %s: %v", path, err)
+			}
+
+			runTableFixture(t, table, fixture)
+		})
+	}
+}
+
+func runTableFixture(t *testing.T, table string, fixture tableFixture) {
+	stub := newOpenAPIStub(t, fixture.Interactions...)
+	connection := newTestConnection(t, stub, fixture.Config)
+
+	rows, err := connection.query(table, fixture.Columns, fixture.Quals, fixture.Limit)
+	switch {
+	case fixture.Error != "" && err == nil:
+		t.Fatalf("expected error containing %q, got %d rows", fixture.Error, len(rows))
+	case fixture.Error != "" && !strings.Contains(err.Error(), fixture.Error):
+		t.Fatalf("expected error containing %q, got: %v", fixture.Error, err)
+	case fixture.Error == "" && err != nil:
+		t.Fatalf("query failed: %v", err)
+	}
+
+	if fixture.Error == "" {
+		compareRows(t, fixture.Columns, fixture.Rows, rows)
+	}
+	stub.verify(t)
+}
+
+// compareRows compares the rows on the given columns regardless of their order.
+// A column missing from an expected row is expected to be null.
+func compareRows(t *testing.T, columns []string, expected, actual []map[string]any) {
+	t.Helper()
+
+	project := func(rows []map[string]any) []string {
+		result := make([]string, 0, len(rows))
+		for _, row := range rows {
+			projected := map[string]any{}
+			for _, column := range columns {
+				projected[column] = row[column]
+			}
+			data, err := json.Marshal(projected)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result = append(result, string(data))
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	want, got := project(expected), project(actual)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("rows do not match\nwant:\n  %s\ngot:\n  %s", strings.Join(want, "\n  "), strings.Join(got, "\n  "))
+	}
+}
@@ -234,7 +234,7 @@ func (filters *QueryFilters) String() (string, error) {
 
 func logQueryError(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, key string, err error, extra ...any) {
 	// Do not pollute the logs with error messages for missing/disable services
-	if !shouldIgnoreErrorPluginDefault()(ctx, d, h, err) {
+	if _, ignored := ignoredErrorReason(ctx, d, nil, err); !ignored {
 		info := []any{"connection_error", err}
 		info = append(info, extra...)
 		plugin.Logger(ctx).Error(key, info...)